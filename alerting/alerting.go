@@ -2,25 +2,108 @@ package alerting
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"maps"
 	"net/http"
-	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"text/template"
 	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 )
 
 // Event contains non-secret metadata about a leaked credential finding.
 type Event struct {
-	Repository string    `json:"repository"`
-	Branch     string    `json:"branch"`
-	CommitSHA  string    `json:"commit_sha"`
-	Rule       string    `json:"rule"`
-	FilePath   string    `json:"file_path"`
-	Author     string    `json:"author"`
-	DetectedAt time.Time `json:"detected_at"`
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+	CommitSHA  string `json:"commit_sha"`
+	Rule       string `json:"rule"`
+	FilePath   string `json:"file_path"`
+	// LineNumber and ColumnNumber are 1-based; zero means unknown.
+	LineNumber   int       `json:"line_number,omitempty"`
+	ColumnNumber int       `json:"column_number,omitempty"`
+	Author       string    `json:"author"`
+	DetectedAt   time.Time `json:"detected_at"`
+	Synthetic    bool      `json:"synthetic,omitempty"`
+	// Severity is optional; SeverityUnset means DefaultSeverity.
+	Severity Severity `json:"severity,omitempty"`
+	// SecretPreview may hold the leaked value itself. Renderers only ever
+	// show Redact(SecretPreview), and it is never marshaled with the Event.
+	SecretPreview string `json:"-"`
+	// MaskedContext is the matched line with the secret already masked,
+	// usually built with MaskSpan.
+	MaskedContext string `json:"masked_context,omitempty"`
+	// ContextLines are the lines surrounding the finding, already redacted
+	// by the producer. Slack shows up to SlackMaxContextLines of them.
+	ContextLines []string `json:"context_lines,omitempty"`
+	// Provider, when set, adds a CommitURL link to Slack and webhook
+	// payloads. ProviderURL is the base URL of a self-hosted instance.
+	Provider    GitProvider `json:"provider,omitempty"`
+	ProviderURL string      `json:"provider_url,omitempty"`
+	// Occurrences counts the findings a Throttle coalesced into this
+	// event; zero and one both mean a single finding.
+	Occurrences int `json:"occurrences,omitempty"`
+	// Fields carries pipeline metadata such as CI job IDs or PR numbers.
+	// Keys must not collide with the core field names; see Validate.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// reservedFieldKeys are the core Event and WebhookPayload JSON names that
+// Event.Fields keys may not reuse.
+var reservedFieldKeys = map[string]bool{
+	"event": true, "schema_version": true, "repository": true, "branch": true, "commit_sha": true,
+	"rule": true, "file_path": true, "line_number": true, "column_number": true,
+	"author": true, "detected_at": true, "severity": true, "synthetic": true,
+	"secret_preview": true, "masked_context": true, "context_lines": true, "provider": true,
+	"provider_url": true, "commit_url": true, "occurrences": true,
+	"fields": true, "extra": true,
+}
+
+// Location returns the file path with the line and column appended when
+// known, e.g. "config/settings.py:42" or "config/settings.py:42:7".
+func (e Event) Location() string {
+	if e.LineNumber <= 0 {
+		return e.FilePath
+	}
+	if e.ColumnNumber <= 0 {
+		return fmt.Sprintf("%s:%d", e.FilePath, e.LineNumber)
+	}
+	return fmt.Sprintf("%s:%d:%d", e.FilePath, e.LineNumber, e.ColumnNumber)
+}
+
+// Fingerprint identifies a finding independently of the commit and time it
+// was seen: a SHA-256 hex digest over the repository, rule, file path, and
+// line. Events with an unknown line hash only the first three, so keys
+// recorded before line numbers existed still match.
+func (e Event) Fingerprint() string {
+	parts := []string{e.Repository, e.Rule, e.FilePath}
+	if e.LineNumber > 0 {
+		parts = append(parts, strconv.Itoa(e.LineNumber))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// IdempotencyKey identifies one delivery of a finding: its Fingerprint
+// scoped to the commit, so retries of the same event share a key while the
+// same secret reintroduced by a later commit gets a new one.
+func (e Event) IdempotencyKey() string {
+	sum := sha256.Sum256([]byte(e.Fingerprint() + "\x00" + e.CommitSHA))
+	return hex.EncodeToString(sum[:])
 }
 
 func (e Event) Validate() error {
@@ -33,30 +116,76 @@ func (e Event) Validate() error {
 	if strings.TrimSpace(e.CommitSHA) == "" {
 		return errors.New("commit_sha is required")
 	}
+	if err := validateCommitSHA(e.CommitSHA); err != nil {
+		return err
+	}
 	if strings.TrimSpace(e.Rule) == "" {
 		return errors.New("rule is required")
 	}
 	if strings.TrimSpace(e.FilePath) == "" {
 		return errors.New("file_path is required")
 	}
+	if e.LineNumber < 0 || e.ColumnNumber < 0 {
+		return errors.New("line_number and column_number must not be negative")
+	}
 	if strings.TrimSpace(e.Author) == "" {
 		return errors.New("author is required")
 	}
 	if e.DetectedAt.IsZero() {
 		return errors.New("detected_at is required")
 	}
+	if err := e.Severity.Validate(); err != nil {
+		return err
+	}
+	for key := range e.Fields {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("fields keys must not be empty")
+		}
+		if reservedFieldKeys[strings.ToLower(key)] {
+			return fmt.Errorf("fields key %q is reserved", key)
+		}
+	}
 	return nil
 }
 
+// Commit SHAs may be abbreviated to MinCommitSHALen hex digits, git's own
+// default, and are at most MaxCommitSHALen, the length of a SHA-256 object
+// name.
+const (
+	MinCommitSHALen = 7
+	MaxCommitSHALen = 64
+)
+
+func validateCommitSHA(sha string) error {
+	if len(sha) < MinCommitSHALen || len(sha) > MaxCommitSHALen {
+		return fmt.Errorf("commit_sha must be %d to %d hex digits, got %d characters", MinCommitSHALen, MaxCommitSHALen, len(sha))
+	}
+	for _, r := range sha {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return fmt.Errorf("commit_sha %q is not hexadecimal", sha)
+		}
+	}
+	return nil
+}
+
+// DiscordContentLimit is the maximum length of a Discord message content.
+const DiscordContentLimit = 2000
+
 // DiscordEmbed represents a Discord rich embed object.
 type DiscordEmbed struct {
 	Title       string              `json:"title"`
 	Description string              `json:"description"`
 	Color       int                 `json:"color"`
 	Fields      []DiscordEmbedField `json:"fields"`
+	Footer      *DiscordEmbedFooter `json:"footer,omitempty"`
 	Timestamp   string              `json:"timestamp,omitempty"`
 }
 
+// DiscordEmbedFooter represents the footer line of a Discord embed.
+type DiscordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
 // DiscordEmbedField represents a field inside a Discord embed.
 type DiscordEmbedField struct {
 	Name   string `json:"name"`
@@ -70,16 +199,18 @@ type DiscordPayload struct {
 	Embeds  []DiscordEmbed `json:"embeds"`
 }
 
-func BuildDiscordPayload(event Event) DiscordPayload {
-	shortSHA := event.CommitSHA
-	if len(shortSHA) > 7 {
-		shortSHA = shortSHA[:7]
-	}
+// summaryLine is the plain one-line description of a finding.
+func summaryLine(event Event, opts ...RenderOption) string {
+	cfg := newRenderConfig(opts)
+	return fmt.Sprintf("Secret detected in %s on %s (%s)", event.Repository, event.Branch, cfg.shortSHA(event.CommitSHA))
+}
 
-	summary := fmt.Sprintf("🚨 Secret detected in %s on %s (%s)", event.Repository, event.Branch, shortSHA)
+func BuildDiscordPayload(event Event, opts ...RenderOption) DiscordPayload {
+	cfg := newRenderConfig(opts)
+	summary := "🚨 " + summaryLine(event, opts...)
 
 	return DiscordPayload{
-		Content: summary,
+		Content: truncateRunes(summary, DiscordContentLimit),
 		Embeds: []DiscordEmbed{
 			{
 				Title:       "Secret Leak Detected",
@@ -94,146 +225,812 @@ func BuildDiscordPayload(event Event) DiscordPayload {
 					{Name: "Author", Value: fmt.Sprintf("`%s`", event.Author), Inline: true},
 					{Name: "Detected At", Value: fmt.Sprintf("`%s`", event.DetectedAt.UTC().Format(time.RFC3339)), Inline: false},
 				},
+				Footer:    &DiscordEmbedFooter{Text: "Commit " + cfg.shortSHA(event.CommitSHA)},
 				Timestamp: event.DetectedAt.UTC().Format(time.RFC3339),
 			},
 		},
 	}
 }
 
+// truncateRunes shortens s to at most limit runes, marking the cut with an
+// ellipsis.
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:limit-1]) + "…"
+}
+
 type SlackText struct {
 	Type string `json:"type"`
 	Text string `json:"text"`
 }
 
 type SlackBlock struct {
-	Type string    `json:"type"`
-	Text SlackText `json:"text,omitempty"`
+	Type     string         `json:"type"`
+	Text     SlackText      `json:"text,omitzero"`
+	BlockID  string         `json:"block_id,omitempty"`
+	Elements []SlackElement `json:"elements,omitempty"`
 }
 
-type SlackPayload struct {
-	Text   string       `json:"text"`
+// SlackElement is an element of a context or actions block: a SlackText
+// or a SlackButton.
+type SlackElement interface {
+	slackElement()
+}
+
+func (SlackText) slackElement() {}
+
+// SlackButton is an interactive button element.
+type SlackButton struct {
+	Type     string    `json:"type"`
+	Text     SlackText `json:"text"`
+	ActionID string    `json:"action_id"`
+	Value    string    `json:"value,omitempty"`
+	Style    string    `json:"style,omitempty"`
+}
+
+func (SlackButton) slackElement() {}
+
+// SlackAttachment is a legacy attachment, used for its color bar.
+type SlackAttachment struct {
+	Color  string       `json:"color"`
 	Blocks []SlackBlock `json:"blocks,omitempty"`
 }
 
-func BuildSlackPayload(event Event) SlackPayload {
-	shortSHA := event.CommitSHA
-	if len(shortSHA) > 7 {
-		shortSHA = shortSHA[:7]
-	}
+type SlackPayload struct {
+	Text        string            `json:"text"`
+	Blocks      []SlackBlock      `json:"blocks,omitempty"`
+	Attachments []SlackAttachment `json:"attachments,omitempty"`
+}
+
+// SlackMaxContextBlocks caps the extra context blocks appended by
+// WithSlackContext, keeping messages well inside Slack's 50-block limit.
+const SlackMaxContextBlocks = 10
 
-	summary := fmt.Sprintf(":rotating_light: Secret detected in %s on %s (%s)", event.Repository, event.Branch, shortSHA)
-	return SlackPayload{
+const (
+	// SlackMaxContextLines caps the Event.ContextLines shown in one alert.
+	SlackMaxContextLines = 5
+	// SlackMaxContextLineLen caps each shown line, in runes.
+	SlackMaxContextLineLen = 120
+)
+
+// SlackContextPair is one labelled line of extra Slack context.
+type SlackContextPair struct {
+	Label string
+	Value string
+}
+
+func BuildSlackPayload(event Event, opts ...RenderOption) SlackPayload {
+	cfg := newRenderConfig(opts)
+	summary := ":rotating_light: " + summaryLine(event, opts...)
+	payload := SlackPayload{
 		Text: summary,
 		Blocks: []SlackBlock{
 			{
 				Type: "section",
 				Text: SlackText{
 					Type: "mrkdwn",
-					Text: "*Secret Leak Detected*",
+					Text: "*Secret Leak Detected*" + slackOccurrences(event),
 				},
 			},
 			{
 				Type: "section",
 				Text: SlackText{
 					Type: "mrkdwn",
-					Text: fmt.Sprintf("*Repository:* `%s`\n*Branch:* `%s`\n*Commit:* `%s`\n*Rule:* `%s`\n*File:* `%s`\n*Author:* `%s`\n*Detected At:* `%s`",
-						event.Repository,
-						event.Branch,
-						event.CommitSHA,
-						event.Rule,
-						event.FilePath,
-						event.Author,
-						event.DetectedAt.UTC().Format(time.RFC3339),
-					),
+					Text: slackDetail(event, cfg),
 				},
 			},
 		},
 	}
+	if link := event.commitLink(); link != "" {
+		payload.Blocks = append(payload.Blocks, SlackBlock{
+			Type: "section",
+			Text: SlackText{
+				Type: "mrkdwn",
+				Text: "<" + link + "|View file at " + cfg.shortSHA(event.CommitSHA) + ">",
+			},
+		})
+	}
+	if event.MaskedContext != "" {
+		payload.Blocks = append(payload.Blocks, SlackBlock{
+			Type: "section",
+			Text: SlackText{
+				Type: "mrkdwn",
+				Text: "```" + slackCodeEscape(truncateRunes(event.MaskedContext, SlackMaxContextLineLen)) + "```",
+			},
+		})
+	}
+	if code := slackCodeContext(event.ContextLines, cfg); code != "" {
+		payload.Blocks = append(payload.Blocks, SlackBlock{
+			Type: "section",
+			Text: SlackText{Type: "mrkdwn", Text: code},
+		})
+	}
+	payload.Blocks = append(payload.Blocks, slackContextBlocks(cfg.slackContext)...)
+	if cfg.slackActions {
+		payload.Blocks = append(payload.Blocks, slackActionsBlock(event, cfg.slackCallbackID))
+	}
+	severity := event.Severity.OrDefault()
+	payload.Attachments = []SlackAttachment{{
+		Color: severity.SlackColor(),
+		Blocks: []SlackBlock{{
+			Type: "section",
+			Text: SlackText{Type: "mrkdwn", Text: fmt.Sprintf("*Severity:* `%s`", severity)},
+		}},
+	}}
+	return payload
+}
+
+// slackCodeContext renders the first SlackMaxContextLines lines as a code
+// block, or "" when there are none. Redacting them is the producer's job;
+// beyond any WithContextSanitizer pass they are only shortened and escaped.
+func slackCodeContext(lines []string, cfg renderConfig) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	if cfg.sanitizeContext != nil {
+		lines = cfg.sanitizeContext(lines)
+	}
+	shown := make([]string, 0, min(len(lines), SlackMaxContextLines))
+	for _, line := range lines[:min(len(lines), SlackMaxContextLines)] {
+		shown = append(shown, slackCodeEscape(truncateRunes(line, SlackMaxContextLineLen)))
+	}
+	return "```\n" + strings.Join(shown, "\n") + "\n```"
+}
+
+// slackContextBlocks renders one context block per pair. Pairs beyond
+// SlackMaxContextBlocks are folded into a final "more" note.
+func slackContextBlocks(pairs []SlackContextPair) []SlackBlock {
+	if len(pairs) == 0 {
+		return nil
+	}
+	shown := pairs
+	if len(pairs) > SlackMaxContextBlocks {
+		shown = pairs[:SlackMaxContextBlocks-1]
+	}
+	blocks := make([]SlackBlock, 0, len(shown)+1)
+	for _, pair := range shown {
+		blocks = append(blocks, slackContextBlock(fmt.Sprintf("*%s:* %s", slackEscape(pair.Label), slackEscape(pair.Value))))
+	}
+	if hidden := len(pairs) - len(shown); hidden > 0 {
+		blocks = append(blocks, slackContextBlock(fmt.Sprintf("_+%d more_", hidden)))
+	}
+	return blocks
+}
+
+func slackContextBlock(text string) SlackBlock {
+	return SlackBlock{
+		Type:     "context",
+		Elements: []SlackElement{SlackText{Type: "mrkdwn", Text: text}},
+	}
+}
+
+const (
+	SlackActionAcknowledge   = "tripwire_ack"
+	SlackActionFalsePositive = "tripwire_false_positive"
+)
+
+// slackActionsBlock renders the triage buttons. Each button's value is the
+// finding's fingerprint so the interactivity handler can look it up.
+func slackActionsBlock(event Event, callbackID string) SlackBlock {
+	fingerprint := event.Fingerprint()
+	return SlackBlock{
+		Type:    "actions",
+		BlockID: callbackID,
+		Elements: []SlackElement{
+			SlackButton{
+				Type:     "button",
+				Text:     SlackText{Type: "plain_text", Text: "Acknowledge"},
+				ActionID: SlackActionAcknowledge,
+				Value:    fingerprint,
+				Style:    "primary",
+			},
+			SlackButton{
+				Type:     "button",
+				Text:     SlackText{Type: "plain_text", Text: "False positive"},
+				ActionID: SlackActionFalsePositive,
+				Value:    fingerprint,
+			},
+		},
+	}
+}
+
+// slackEscape escapes the characters Slack treats as control sequences in
+// mrkdwn text.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// slackOccurrences notes how many findings a Throttle coalesced into
+// event, or "" for a single finding.
+func slackOccurrences(event Event) string {
+	if event.Occurrences <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d occurrences)", event.Occurrences)
 }
 
+// slackCodeEscape escapes s for a Slack code block: mentions and links are
+// neutralized, and runs of backticks are split with a zero-width space so
+// they cannot close the block early.
+func slackCodeEscape(s string) string {
+	return strings.ReplaceAll(slackEscape(s), "``", "`\u200b`")
+}
+
+// slackInlineCodeEscape escapes s for a Slack inline code span, which has
+// no way to quote a backtick: backticks become U+02CB so they cannot end
+// the span.
+func slackInlineCodeEscape(s string) string {
+	return strings.ReplaceAll(slackEscape(s), "`", "\u02cb")
+}
+
+func slackDetail(event Event, cfg renderConfig) string {
+	return formatDetail(event, DetailMrkdwn, cfg)
+}
+
+// WebhookSchemaVersion is the WebhookPayload shape, sent as
+// schema_version. Bump the minor version when fields are added and the
+// major version when any are renamed, retyped, or removed.
+//
+//	1.0  initial payload
+//	1.1  severity, line and column numbers, secret_preview, commit_url,
+//	     occurrences, and extra
+const WebhookSchemaVersion = "1.1"
+
 type WebhookPayload struct {
-	Event      string `json:"event"`
-	Repository string `json:"repository"`
-	Branch     string `json:"branch"`
-	CommitSHA  string `json:"commit_sha"`
-	Rule       string `json:"rule"`
-	FilePath   string `json:"file_path"`
-	Author     string `json:"author"`
-	DetectedAt string `json:"detected_at"`
+	Event         string `json:"event"`
+	SchemaVersion string `json:"schema_version"`
+	Repository    string `json:"repository"`
+	Branch        string `json:"branch"`
+	CommitSHA     string `json:"commit_sha"`
+	Rule          string `json:"rule"`
+	FilePath      string `json:"file_path"`
+	LineNumber    int    `json:"line_number,omitempty"`
+	ColumnNumber  int    `json:"column_number,omitempty"`
+	Author        string `json:"author"`
+	DetectedAt    string `json:"detected_at"`
+	Severity      string `json:"severity"`
+	Synthetic     bool   `json:"synthetic,omitempty"`
+	// SecretPreview is the Redact form of Event.SecretPreview.
+	SecretPreview string `json:"secret_preview,omitempty"`
+	// CommitURL links to the file at the commit when Event.Provider is set.
+	CommitURL   string `json:"commit_url,omitempty"`
+	Occurrences int    `json:"occurrences,omitempty"`
+	// Extra holds Event.Fields, kept apart so they cannot clobber the
+	// standard fields.
+	Extra map[string]string `json:"extra,omitempty"`
 }
 
 func BuildWebhookPayload(event Event) WebhookPayload {
-	return WebhookPayload{
-		Event:      "secret.detected",
-		Repository: event.Repository,
-		Branch:     event.Branch,
-		CommitSHA:  event.CommitSHA,
-		Rule:       event.Rule,
-		FilePath:   event.FilePath,
-		Author:     event.Author,
-		DetectedAt: event.DetectedAt.UTC().Format(time.RFC3339),
+	payload := WebhookPayload{
+		Event:         "secret.detected",
+		SchemaVersion: WebhookSchemaVersion,
+		Repository:    event.Repository,
+		Branch:        event.Branch,
+		CommitSHA:     event.CommitSHA,
+		Rule:          event.Rule,
+		FilePath:      event.FilePath,
+		LineNumber:    event.LineNumber,
+		ColumnNumber:  event.ColumnNumber,
+		Author:        event.Author,
+		DetectedAt:    event.DetectedAt.UTC().Format(time.RFC3339),
+		Severity:      event.Severity.OrDefault().String(),
+		Synthetic:     event.Synthetic,
+		CommitURL:     event.commitLink(),
+		Occurrences:   event.Occurrences,
+		Extra:         maps.Clone(event.Fields),
 	}
+	if event.SecretPreview != "" {
+		payload.SecretPreview = Redact(event.SecretPreview)
+	}
+	return payload
+}
+
+// HTTPDoer is the subset of *http.Client used to deliver alerts, so callers
+// can wrap the client with retries, tracing, or test fakes.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
 }
 
 type Sender struct {
-	Client *http.Client
+	Doer HTTPDoer
+
+	// RenderOptions are applied when building chat payloads in SendDiscord
+	// and SendSlack.
+	RenderOptions []RenderOption
+
+	// SlackTemplate, when set, replaces the built-in Slack message format in
+	// SendSlack. See ParseSlackTemplate.
+	SlackTemplate *template.Template
+
+	// SuccessStatuses lists extra status codes treated as a successful
+	// delivery on top of the 2xx range, e.g. 208 Already Reported.
+	SuccessStatuses []int
+	// SuccessStatusesOnly makes SuccessStatuses replace the 2xx range
+	// instead of augmenting it.
+	SuccessStatusesOnly bool
+
+	// PrettyJSON indents outbound bodies for easier debugging. Leave it off
+	// in production to keep payloads small.
+	PrettyJSON bool
+
+	// SMTPDialer opens connections for SendEmail; nil uses a net.Dialer.
+	SMTPDialer SMTPDialer
+
+	// SigningSecret, when set, makes SendWebhook encode each body with
+	// CanonicalJSON and sign it with HMAC-SHA256 in the
+	// X-Tripwire-Signature header.
+	SigningSecret []byte
+	// Clock stamps signed requests and resolves Retry-After dates; nil
+	// uses RealClock.
+	Clock Clock
+
+	// Retry controls redelivery after connection errors, 5xx, and 429
+	// responses. The zero value sends once.
+	Retry RetryPolicy
+
+	// Logger records every delivery attempt; nil discards. Only the
+	// webhook host and path are logged, never the query string.
+	Logger *slog.Logger
+
+	// Metrics receives delivery counters and latencies; nil records
+	// nothing.
+	Metrics Metrics
+
+	// TracerProvider supplies the tracer for one SendSpanName span per
+	// delivery; nil uses the global provider, which is a no-op until one
+	// is installed.
+	TracerProvider trace.TracerProvider
+
+	// RequestHook runs on every outbound request after it is built and
+	// signed, just before it is sent. It may set dynamic headers or replace
+	// the request context; returning an error aborts the send.
+	RequestHook func(*http.Request) error
+
+	// AllowInsecure permits http URLs and loopback or link-local hosts,
+	// which are otherwise rejected; see ValidateWebhookURL. Only enable it
+	// for local testing.
+	AllowInsecure bool
+
+	// Allowlist, when set, silently drops matching events before they are
+	// sent; the send reports success.
+	Allowlist *Allowlist
+	// Baseline, when set, drops previously triaged events the same way.
+	Baseline *Baseline
+
+	// OnResult, when set, is called once per event send with the final
+	// outcome after any retries: a nil error on success. Suppressed events
+	// and batch sends are not reported. A panic in OnResult is recovered
+	// and logged.
+	OnResult func(event Event, destination string, err error)
+
+	limiter *rate.Limiter
+	timeout time.Duration
+	headers http.Header
+	// userAgent overrides DefaultUserAgent when set.
+	userAgent string
+	// globalLimitUntil is the Unix nanosecond time a global 429 resets;
+	// every send waits for it.
+	globalLimitUntil atomic.Int64
+
+	hashAuthors  bool
+	authorSalt   []byte
+	gzipMinBytes int
+}
+
+// NewSender returns a Sender that delivers through doer, which is usually
+// an *http.Client. A nil doer uses http.DefaultClient.
+func NewSender(doer HTTPDoer, opts ...SenderOption) *Sender {
+	s := &Sender{Doer: doerOrDefault(doer)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
 }
 
-func NewSender(client *http.Client) *Sender {
-	if client == nil {
-		client = http.DefaultClient
+func doerOrDefault(doer HTTPDoer) HTTPDoer {
+	if client, ok := doer.(*http.Client); doer == nil || (ok && client == nil) {
+		return http.DefaultClient
 	}
-	return &Sender{Client: client}
+	return doer
 }
 
 func (s *Sender) SendDiscord(ctx context.Context, webhookURL string, event Event) error {
 	if err := event.Validate(); err != nil {
 		return fmt.Errorf("invalid event: %w", err)
 	}
-	return s.sendJSON(ctx, webhookURL, BuildDiscordPayload(event))
+	event = s.prepareEvent(event)
+	return s.sendJSON(ctx, "discord", webhookURL, &event, BuildDiscordPayload(event, s.RenderOptions...))
 }
 
 func (s *Sender) SendSlack(ctx context.Context, webhookURL string, event Event) error {
 	if err := event.Validate(); err != nil {
 		return fmt.Errorf("invalid event: %w", err)
 	}
-	return s.sendJSON(ctx, webhookURL, BuildSlackPayload(event))
+	event = s.prepareEvent(event)
+	payload, err := BuildSlackPayloadTemplate(event, s.SlackTemplate, s.RenderOptions...)
+	if err != nil {
+		return err
+	}
+	return s.sendJSON(ctx, "slack", webhookURL, &event, payload)
 }
 
 func (s *Sender) SendWebhook(ctx context.Context, webhookURL string, event Event) error {
 	if err := event.Validate(); err != nil {
 		return fmt.Errorf("invalid event: %w", err)
 	}
-	return s.sendJSON(ctx, webhookURL, BuildWebhookPayload(event))
+	event = s.prepareEvent(event)
+	return s.sendPayload(ctx, outboundRequest{
+		destination: "webhook",
+		method:      http.MethodPost,
+		url:         webhookURL,
+		contentType: "application/json",
+		header:      http.Header{IdempotencyKeyHeader: {event.IdempotencyKey()}},
+		sign:        true,
+		event:       &event,
+	}, BuildWebhookPayload(event))
 }
 
-func (s *Sender) sendJSON(ctx context.Context, webhookURL string, payload any) error {
-	if strings.TrimSpace(webhookURL) == "" {
-		return errors.New("webhook URL is required")
+// outboundRequest describes a single delivery to a destination.
+type outboundRequest struct {
+	destination string
+	method      string
+	url         string
+	contentType string
+	header      http.Header
+	// secretInURL is redacted from logged paths and transport errors, for
+	// APIs that carry a credential in the URL path.
+	secretInURL string
+	// sign adds the HMAC signature headers when the Sender has a secret.
+	sign bool
+	// authorize, when set, adds destination-specific credentials to each
+	// attempt's request, after the HMAC signature and before RequestHook.
+	authorize func(ctx context.Context, req *http.Request, body []byte) error
+	// event is the finding being delivered, for logging; nil for batches.
+	event *Event
+	// response, when set, receives the decoded JSON body of a successful
+	// response.
+	response any
+	// contentEncoding is set by sendPayload when it compresses the body.
+	contentEncoding string
+}
+
+// rawPayload is sent as-is instead of being JSON encoded, for formats such
+// as NDJSON.
+type rawPayload []byte
+
+func (s *Sender) sendJSON(ctx context.Context, destination, webhookURL string, event *Event, payload any) error {
+	return s.sendPayload(ctx, outboundRequest{
+		destination: destination,
+		method:      http.MethodPost,
+		url:         webhookURL,
+		contentType: "application/json",
+		event:       event,
+	}, payload)
+}
+
+// prepareEvent applies the Sender's event rewrites, such as author hashing,
+// before a payload is built from event.
+func (s *Sender) prepareEvent(event Event) Event {
+	if s.hashAuthors {
+		event = event.MaskAuthor(s.authorSalt)
+	}
+	return event
+}
+
+// prepareEvents is prepareEvent over a batch; events itself is not
+// modified.
+func (s *Sender) prepareEvents(events []Event) []Event {
+	if !s.hashAuthors {
+		return events
+	}
+	prepared := make([]Event, len(events))
+	for i, event := range events {
+		prepared[i] = s.prepareEvent(event)
+	}
+	return prepared
+}
+
+// suppressed reports whether event is dropped by the allowlist or baseline.
+func (s *Sender) suppressed(event Event) bool {
+	return s.Allowlist.ShouldSuppress(event) || s.Baseline.Contains(event)
+}
+
+// report passes the outcome of one event send to OnResult. A nil event,
+// from a batch send, is not reported.
+func (s *Sender) report(ctx context.Context, event *Event, destination string, err error) {
+	if s.OnResult == nil || event == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil && s.Logger != nil {
+			s.Logger.ErrorContext(ctx, "OnResult callback panicked",
+				slog.String("destination", destination), slog.Any("panic", r))
+		}
+	}()
+	s.OnResult(*event, destination, err)
+}
+
+func (s *Sender) sendPayload(ctx context.Context, out outboundRequest, payload any) error {
+	if out.event != nil && s.suppressed(*out.event) {
+		return nil
 	}
-	if _, err := url.ParseRequestURI(webhookURL); err != nil {
-		return fmt.Errorf("invalid webhook URL: %w", err)
+	ctx, span := s.startSendSpan(ctx, out)
+	err := s.deliver(ctx, out, payload)
+	endSendSpan(span, out, err)
+	s.report(ctx, out.event, out.destination, err)
+	return err
+}
+
+// deliver validates the URL, encodes payload, and runs the retry loop.
+func (s *Sender) deliver(ctx context.Context, out outboundRequest, payload any) error {
+	if err := ValidateWebhookURL(out.url, s.AllowInsecure); err != nil {
+		return err
 	}
+	out = liftBasicAuth(out)
 
-	body, err := json.Marshal(payload)
+	body, err := s.marshal(payload, out.sign && len(s.SigningSecret) > 0)
 	if err != nil {
-		return fmt.Errorf("marshal payload: %w", err)
+		return fmt.Errorf("marshal %s payload (%T): %w", out.destination, payload, err)
+	}
+	if s.gzipMinBytes > 0 && len(body) > s.gzipMinBytes {
+		if body, err = gzipBody(body); err != nil {
+			return fmt.Errorf("compress %s payload: %w", out.destination, err)
+		}
+		out.contentEncoding = "gzip"
+	}
+
+	metrics := s.metrics()
+	first := time.Now()
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		retryable, status, err := s.attempt(ctx, out, body)
+		metrics.ObserveLatency(out.destination, time.Since(start))
+		recordAttempt(ctx, attempt, status)
+		if err == nil {
+			metrics.IncSent(out.destination)
+			s.logAttempt(ctx, slog.LevelInfo, out, attempt, nil)
+			return nil
+		}
+		giveUp := !retryable || attempt >= s.Retry.attempts()
+		var delay time.Duration
+		if !giveUp {
+			delay = s.retryDelay(attempt, err)
+			giveUp = s.Retry.exhausted(time.Since(first) + delay)
+		}
+		if giveUp {
+			metrics.IncFailed(out.destination, failureReason(err))
+			s.logAttempt(ctx, slog.LevelError, out, attempt, err)
+			return newSendError(out.destination, retryable, err)
+		}
+		metrics.IncRetried(out.destination)
+		s.logAttempt(ctx, slog.LevelWarn, out, attempt, err)
+		if waitErr := sleepContext(ctx, delay); waitErr != nil {
+			metrics.IncFailed(out.destination, failureReason(waitErr))
+			return newSendError(out.destination, true, fmt.Errorf("retry %s send: %w (last error: %v)", out.destination, waitErr, err))
+		}
+	}
+}
+
+// attempt performs one delivery and reports the response status, or 0 when
+// none arrived, and whether a failure is worth retrying.
+func (s *Sender) attempt(ctx context.Context, out outboundRequest, body []byte) (retryable bool, status int, err error) {
+	// Wait for the limiters before starting the request timeout, so time
+	// spent queued does not count against it.
+	if s.limiter != nil {
+		if err := s.limiter.Wait(ctx); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return false, 0, canceledError(out.destination, ctxErr)
+			}
+			return false, 0, fmt.Errorf("rate limit %s send: %w", out.destination, err)
+		}
+	}
+	if err := s.waitForGlobalLimit(ctx); err != nil {
+		return false, 0, canceledError(out.destination, err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	reqCtx, cancel := context.WithTimeout(ctx, orDefault(s.timeout, DefaultSendTimeout))
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, out.method, out.url, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("build request: %w", err)
+		return false, 0, fmt.Errorf("build request: %w", redactURLError(err, out.secretInURL))
+	}
+	req.Header.Set("User-Agent", cmp.Or(s.userAgent, DefaultUserAgent()))
+	for name, values := range s.headers {
+		req.Header[name] = slices.Clone(values)
+	}
+	for name, values := range out.header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", out.contentType)
+	if out.contentEncoding != "" {
+		req.Header.Set("Content-Encoding", out.contentEncoding)
+	}
+	if out.sign && len(s.SigningSecret) > 0 {
+		s.signRequest(req, body)
+	}
+	if out.authorize != nil {
+		if err := out.authorize(reqCtx, req, body); err != nil {
+			return false, 0, fmt.Errorf("authorize %s request: %w", out.destination, err)
+		}
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := s.Client.Do(req)
+	if s.RequestHook != nil {
+		if err := s.RequestHook(req); err != nil {
+			return false, 0, fmt.Errorf("%s request hook: %w", out.destination, err)
+		}
+	}
+	resp, err := s.Doer.Do(req)
 	if err != nil {
-		return fmt.Errorf("send webhook: %w", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return false, 0, canceledError(out.destination, ctxErr)
+		}
+		return true, 0, fmt.Errorf("send webhook: %w", redactURLError(err, out.secretInURL))
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	if !s.isSuccess(resp.StatusCode) {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		statusErr := &statusError{StatusCode: resp.StatusCode, header: resp.Header, body: errBody}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			var global bool
+			statusErr.RetryAfter, global = rateLimitWait(resp.Header, errBody, s.now())
+			if global && statusErr.RetryAfter > 0 {
+				s.pauseForGlobalLimit(s.now().Add(statusErr.RetryAfter))
+			}
+		}
+		return retryableStatus(resp.StatusCode), resp.StatusCode, statusErr
 	}
-	return nil
+	if out.response != nil {
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(out.response); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return false, resp.StatusCode, canceledError(out.destination, ctxErr)
+			}
+			return false, resp.StatusCode, fmt.Errorf("decode %s response: %w", out.destination, err)
+		}
+		return false, resp.StatusCode, nil
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return false, resp.StatusCode, nil
+}
+
+// canceledError reports that the caller's context ended mid-send. It wraps
+// context.Canceled or context.DeadlineExceeded, never the transport error
+// it caused, so callers can tell it apart from a delivery failure.
+func canceledError(destination string, ctxErr error) error {
+	return fmt.Errorf("%s send interrupted: %w", destination, ctxErr)
+}
+
+const (
+	// maxResponseBytes bounds response bodies decoded for a destination.
+	maxResponseBytes = 16 << 20
+	// maxErrorBodyBytes bounds the error response kept on a statusError.
+	maxErrorBodyBytes = 4 << 10
+	// maxErrorDetailBytes bounds how much of that body appears in the
+	// error message.
+	maxErrorDetailBytes = 512
+)
+
+// statusError reports a response outside the accepted success statuses.
+type statusError struct {
+	StatusCode int
+	// RetryAfter is the server-requested wait from a 429 response, if any;
+	// see rateLimitWait.
+	RetryAfter time.Duration
+	// header and body are the response headers and the start of the
+	// response body, for destinations that explain rejections there.
+	header http.Header
+	body   []byte
+}
+
+func (e *statusError) Error() string {
+	msg := fmt.Sprintf("webhook returned status %d", e.StatusCode)
+	if detail := errorDetail(e.body); detail != "" {
+		msg += ": " + detail
+	}
+	return msg
+}
+
+// errorDetail flattens an error response body onto one line, capped at
+// maxErrorDetailBytes so an HTML error page does not swamp the log.
+func errorDetail(body []byte) string {
+	detail := strings.Join(strings.Fields(string(body)), " ")
+	if len(detail) > maxErrorDetailBytes {
+		detail = strings.ToValidUTF8(detail[:maxErrorDetailBytes], "") + "..."
+	}
+	return detail
+}
+
+// SendError is returned when a delivery fails after the request was built,
+// so callers running their own retries can tell transient failures (5xx,
+// 429, connection errors) from permanent ones (other 4xx). Use errors.As
+// to retrieve it; the message is that of the underlying error.
+type SendError struct {
+	Destination string
+	// StatusCode is the HTTP status of the last response, or 0 when none
+	// was received.
+	StatusCode int
+	Retryable  bool
+	Err        error
+}
+
+func newSendError(destination string, retryable bool, err error) *SendError {
+	return &SendError{Destination: destination, StatusCode: responseStatus(err), Retryable: retryable, Err: err}
+}
+
+func (e *SendError) Error() string { return e.Err.Error() }
+
+func (e *SendError) Unwrap() error { return e.Err }
+
+// responseStatus returns the HTTP status carried by a send error, or 0.
+func responseStatus(err error) int {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
+const (
+	SignatureHeader = "X-Tripwire-Signature"
+	TimestampHeader = "X-Tripwire-Timestamp"
+	// IdempotencyKeyHeader carries Event.IdempotencyKey on webhook sends so
+	// receivers can drop duplicates from overlapping retries.
+	IdempotencyKeyHeader = "X-Idempotency-Key"
+)
+
+// signRequest signs the exact bytes being sent, so receivers can verify
+// the body without re-encoding it.
+func (s *Sender) signRequest(req *http.Request, body []byte) {
+	req.Header.Set(SignatureHeader, "sha256="+SignPayload(s.SigningSecret, body))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(s.now().Unix(), 10))
+}
+
+func (s *Sender) now() time.Time {
+	return clockOrDefault(s.Clock).Now()
+}
+
+// SignPayload returns the hex HMAC-SHA256 of body under secret, as sent in
+// the X-Tripwire-Signature header.
+func SignPayload(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// marshal encodes payload for the wire. Signed payloads use CanonicalJSON,
+// indented when PrettyJSON is set, so receivers can reproduce them.
+func (s *Sender) marshal(payload any, signed bool) ([]byte, error) {
+	if raw, ok := payload.(rawPayload); ok {
+		return raw, nil
+	}
+	if signed {
+		body, err := CanonicalJSON(payload)
+		if err != nil || !s.PrettyJSON {
+			return body, err
+		}
+		var indented bytes.Buffer
+		err = json.Indent(&indented, body, "", "  ")
+		return indented.Bytes(), err
+	}
+	if s.PrettyJSON {
+		return json.MarshalIndent(payload, "", "  ")
+	}
+	return json.Marshal(payload)
+}
+
+func (s *Sender) isSuccess(status int) bool {
+	for _, code := range s.SuccessStatuses {
+		if status == code {
+			return true
+		}
+	}
+	if s.SuccessStatusesOnly {
+		return false
+	}
+	return status >= 200 && status < 300
 }
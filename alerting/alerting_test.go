@@ -2,12 +2,23 @@ package alerting
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 )
 
 func testEvent() Event {
@@ -43,6 +54,24 @@ func TestBuildDiscordPayload(t *testing.T) {
 	if !foundRule {
 		t.Fatal("expected rule field in embed")
 	}
+	if len(embed.Fields) != 7 {
+		t.Fatalf("expected 7 embed fields, got %d", len(embed.Fields))
+	}
+	if embed.Footer == nil || !strings.Contains(embed.Footer.Text, "abc1234") {
+		t.Fatalf("expected short SHA in footer, got %+v", embed.Footer)
+	}
+}
+
+func TestBuildDiscordPayloadTruncatesContent(t *testing.T) {
+	event := testEvent()
+	event.Repository = strings.Repeat("r", 3000)
+	payload := BuildDiscordPayload(event)
+	if n := utf8.RuneCountInString(payload.Content); n != DiscordContentLimit {
+		t.Fatalf("expected content truncated to %d chars, got %d", DiscordContentLimit, n)
+	}
+	if !strings.HasSuffix(payload.Content, "…") {
+		t.Fatalf("expected truncation marker, got suffix %q", payload.Content[len(payload.Content)-8:])
+	}
 }
 
 func TestBuildWebhookPayload(t *testing.T) {
@@ -55,6 +84,39 @@ func TestBuildWebhookPayload(t *testing.T) {
 	}
 }
 
+func TestWebhookPayloadSchemaVersion(t *testing.T) {
+	event := testEvent()
+	event.LineNumber, event.ColumnNumber = 3, 9
+	event.Synthetic = true
+	event.SecretPreview = "AKIA" + "IOSFODNN7EXAMPLE"
+	event.Provider = GitHub
+	event.Occurrences = 2
+	event.Fields = map[string]string{"ci_job": "42"}
+	data, err := json.Marshal(BuildWebhookPayload(event))
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if got["schema_version"] != WebhookSchemaVersion {
+		t.Fatalf("expected schema_version %q, got %v", WebhookSchemaVersion, got["schema_version"])
+	}
+
+	// Changing this list changes the payload shape: bump WebhookSchemaVersion.
+	want := []string{
+		"author", "branch", "column_number", "commit_sha", "commit_url",
+		"detected_at", "event", "extra", "file_path", "line_number",
+		"occurrences", "repository", "rule", "schema_version",
+		"secret_preview", "severity", "synthetic",
+	}
+	keys := slices.Sorted(maps.Keys(got))
+	if !slices.Equal(keys, want) {
+		t.Fatalf("payload keys changed for schema %s:\n got %v\nwant %v", WebhookSchemaVersion, keys, want)
+	}
+}
+
 func TestBuildSlackPayload(t *testing.T) {
 	payload := BuildSlackPayload(testEvent())
 	if !strings.Contains(payload.Text, "acme/tripwire") {
@@ -68,6 +130,24 @@ func TestBuildSlackPayload(t *testing.T) {
 	}
 }
 
+func TestBuildSlackPayloadShowsLine(t *testing.T) {
+	event := testEvent()
+	event.LineNumber = 42
+	detail := BuildSlackPayload(event).Blocks[1].Text.Text
+	if !strings.Contains(detail, "*File:* `config/settings.py:42`") {
+		t.Fatalf("expected file:line in the detail block, got %q", detail)
+	}
+
+	event.ColumnNumber = 7
+	detail = BuildSlackPayload(event).Blocks[1].Text.Text
+	if !strings.Contains(detail, "`config/settings.py:42:7`") {
+		t.Fatalf("expected file:line:column in the detail block, got %q", detail)
+	}
+	if got := BuildWebhookPayload(event); got.LineNumber != 42 || got.ColumnNumber != 7 {
+		t.Fatalf("expected line and column in the webhook payload, got %+v", got)
+	}
+}
+
 func TestSendDiscord(t *testing.T) {
 	var got DiscordPayload
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -85,7 +165,7 @@ func TestSendDiscord(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	s := NewSender(srv.Client())
+	s := NewSender(srv.Client(), WithAllowInsecure())
 	if err := s.SendDiscord(context.Background(), srv.URL, testEvent()); err != nil {
 		t.Fatalf("SendDiscord returned error: %v", err)
 	}
@@ -111,7 +191,7 @@ func TestSendSlack(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	s := NewSender(srv.Client())
+	s := NewSender(srv.Client(), WithAllowInsecure())
 	if err := s.SendSlack(context.Background(), srv.URL, testEvent()); err != nil {
 		t.Fatalf("SendSlack returned error: %v", err)
 	}
@@ -126,7 +206,7 @@ func TestSendWebhookNon2xx(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	s := NewSender(srv.Client())
+	s := NewSender(srv.Client(), WithAllowInsecure())
 	err := s.SendWebhook(context.Background(), srv.URL, testEvent())
 	if err == nil {
 		t.Fatal("expected error for non-2xx response")
@@ -136,10 +216,476 @@ func TestSendWebhookNon2xx(t *testing.T) {
 	}
 }
 
+func TestSendWebhookErrorIncludesResponseBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error": "invalid_payload",` + "\n" + `"field": "rule"}`))
+	}))
+	defer srv.Close()
+
+	err := NewSender(srv.Client(), WithAllowInsecure()).SendWebhook(context.Background(), srv.URL, testEvent())
+	want := `webhook returned status 422: {"error": "invalid_payload", "field": "rule"}`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected %q in the error, got %v", want, err)
+	}
+}
+
+func TestSendErrorClassifiesStatus(t *testing.T) {
+	cases := map[int]bool{
+		http.StatusServiceUnavailable: true,
+		http.StatusNotFound:           false,
+	}
+	for status, retryable := range cases {
+		doer := &fakeDoer{status: status}
+		s := NewSender(doer)
+		s.Retry = fastRetry(1)
+		err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent())
+		var sendErr *SendError
+		if !errors.As(err, &sendErr) {
+			t.Fatalf("status %d: expected a *SendError, got %v", status, err)
+		}
+		if sendErr.StatusCode != status || sendErr.Retryable != retryable {
+			t.Fatalf("status %d: got StatusCode %d, Retryable %t", status, sendErr.StatusCode, sendErr.Retryable)
+		}
+		if want := fmt.Sprintf("webhook returned status %d", status); err.Error() != want {
+			t.Fatalf("expected message %q, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestErrorDetailTruncatesLongBodies(t *testing.T) {
+	got := errorDetail([]byte(strings.Repeat("x", maxErrorBodyBytes)))
+	if len(got) != maxErrorDetailBytes+len("...") || !strings.HasSuffix(got, "...") {
+		t.Fatalf("expected a %d-byte detail ending in ..., got %d bytes", maxErrorDetailBytes, len(got))
+	}
+}
+
 func TestEventValidate(t *testing.T) {
 	e := testEvent()
 	e.Repository = ""
 	if err := e.Validate(); err == nil {
 		t.Fatal("expected validation error for empty repository")
 	}
+
+	e = testEvent()
+	e.LineNumber = -1
+	if err := e.Validate(); err == nil {
+		t.Fatal("expected validation error for a negative line number")
+	}
+}
+
+func TestEventValidateCommitSHA(t *testing.T) {
+	cases := []struct {
+		sha     string
+		wantErr string
+	}{
+		{sha: "abc1", wantErr: "7 to 64 hex digits"},
+		{sha: strings.Repeat("a", 65), wantErr: "7 to 64 hex digits"},
+		{sha: "not-a-sha", wantErr: "not hexadecimal"},
+		{sha: "abc1234"},
+		{sha: "0123456789ABCDEF0123456789abcdef01234567"},
+		{sha: strings.Repeat("f", 64)},
+	}
+	for _, tc := range cases {
+		e := testEvent()
+		e.CommitSHA = tc.sha
+		err := e.Validate()
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("%q: unexpected error %v", tc.sha, err)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("%q: expected error containing %q, got %v", tc.sha, tc.wantErr, err)
+		}
+	}
+}
+
+func TestEventFingerprint(t *testing.T) {
+	base := testEvent()
+	other := testEvent()
+	other.CommitSHA = "fedcba9876543210"
+	other.DetectedAt = base.DetectedAt.Add(72 * time.Hour)
+	if base.Fingerprint() != other.Fingerprint() {
+		t.Fatal("expected events differing only in commit and time to share a fingerprint")
+	}
+	if len(base.Fingerprint()) != 64 {
+		t.Fatalf("expected a SHA-256 hex digest, got %q", base.Fingerprint())
+	}
+
+	other = testEvent()
+	other.Rule = "github-token"
+	if base.Fingerprint() == other.Fingerprint() {
+		t.Fatal("expected different rules to produce different fingerprints")
+	}
+	other = testEvent()
+	other.LineNumber = 42
+	if base.Fingerprint() == other.Fingerprint() {
+		t.Fatal("expected a known line to change the fingerprint")
+	}
+}
+
+func TestSendWebhookSuccessStatuses(t *testing.T) {
+	status := http.StatusConflict
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err == nil {
+		t.Fatal("expected 409 to fail without an override")
+	}
+
+	s.SuccessStatuses = []int{http.StatusConflict}
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("expected listed 409 to succeed, got %v", err)
+	}
+
+	status = http.StatusAlreadyReported
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("expected 208 to still succeed via the 2xx range, got %v", err)
+	}
+
+	s.SuccessStatusesOnly = true
+	err := s.SendWebhook(context.Background(), srv.URL, testEvent())
+	if err == nil || !strings.Contains(err.Error(), "status 208") {
+		t.Fatalf("expected status 208 error when the 2xx range is replaced, got %v", err)
+	}
+
+	s.SuccessStatuses = []int{http.StatusAlreadyReported}
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("expected 208 to succeed when listed, got %v", err)
+	}
+}
+
+func TestSendJSONMarshalErrorNamesDestination(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	err := s.sendJSON(context.Background(), "webhook", srv.URL, nil, map[string]any{"bad": make(chan int)})
+	if err == nil {
+		t.Fatal("expected marshal error")
+	}
+	if !strings.Contains(err.Error(), "marshal webhook payload (map[string]interface {})") {
+		t.Fatalf("expected destination and payload type in error, got %v", err)
+	}
+	if called {
+		t.Fatal("expected no HTTP call after a marshal failure")
+	}
+}
+
+type fakeDoer struct {
+	requests []*http.Request
+	status   int
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests = append(d.requests, req)
+	return &http.Response{
+		StatusCode: d.status,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func TestSenderUsesHTTPDoer(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer)
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if len(doer.requests) != 1 || doer.requests[0].URL.Host != "example.com" {
+		t.Fatalf("expected one request through the fake doer, got %d", len(doer.requests))
+	}
+}
+
+func TestNewSenderNilClientDefaults(t *testing.T) {
+	var client *http.Client
+	if s := NewSender(client); s.Doer != http.DefaultClient {
+		t.Fatalf("expected typed nil client to fall back to http.DefaultClient, got %#v", s.Doer)
+	}
+	if s := NewSender(nil); s.Doer != http.DefaultClient {
+		t.Fatalf("expected nil doer to fall back to http.DefaultClient, got %#v", s.Doer)
+	}
+}
+
+func TestSendWebhookPrettyJSON(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if strings.Contains(string(body), "\n") {
+		t.Fatalf("expected compact body by default, got %q", body)
+	}
+
+	s.PrettyJSON = true
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if !strings.Contains(string(body), "\n  \"repository\": \"acme/tripwire\"") {
+		t.Fatalf("expected indented body, got %q", body)
+	}
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("expected indented body to stay valid JSON: %v", err)
+	}
+}
+
+func fixedAlertTime() time.Time {
+	return time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC)
+}
+
+// fakeClock is a manually advanced AfterFuncClock. Callbacks scheduled
+// with AfterFunc run synchronously from Advance once they fall due.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: fixedAlertTime()}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := &fakeTimer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		wasPending := !timer.stopped
+		timer.stopped = true
+		return wasPending
+	}
+}
+
+// Advance moves the clock forward and runs the callbacks that fall due,
+// in order, with the clock unlocked.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []*fakeTimer
+	remaining := c.timers[:0]
+	for _, timer := range c.timers {
+		switch {
+		case timer.stopped:
+		case !timer.at.After(c.now):
+			timer.stopped = true
+			due = append(due, timer)
+		default:
+			remaining = append(remaining, timer)
+		}
+	}
+	c.timers = remaining
+	c.mu.Unlock()
+
+	slices.SortStableFunc(due, func(a, b *fakeTimer) int { return a.at.Compare(b.at) })
+	for _, timer := range due {
+		timer.f()
+	}
+}
+
+func TestSendWebhookSignsBody(t *testing.T) {
+	secret := []byte("shared-secret")
+	var gotSig, gotTimestamp string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSig = r.Header.Get(SignatureHeader)
+		gotTimestamp = r.Header.Get(TimestampHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.SigningSecret = secret
+	s.PrettyJSON = true
+	s.Clock = ClockFunc(fixedAlertTime)
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(gotBody)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(gotSig), []byte(want)) {
+		t.Fatalf("signature mismatch: got %q, want %q", gotSig, want)
+	}
+	if gotTimestamp != strconv.FormatInt(fixedAlertTime().Unix(), 10) {
+		t.Fatalf("unexpected timestamp %q", gotTimestamp)
+	}
+}
+
+func TestSendWebhookUnsignedWithoutSecret(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	if err := NewSender(doer).SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if got := doer.requests[0].Header.Get(SignatureHeader); got != "" {
+		t.Fatalf("expected no signature header, got %q", got)
+	}
+}
+
+func TestSendWebhookIdempotencyKey(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer)
+	other := testEvent()
+	other.FilePath = "config/other.py"
+	for _, event := range []Event{testEvent(), testEvent(), other} {
+		if err := s.SendWebhook(context.Background(), "https://example.com/hook", event); err != nil {
+			t.Fatalf("SendWebhook returned error: %v", err)
+		}
+	}
+
+	first := doer.requests[0].Header.Get(IdempotencyKeyHeader)
+	if first == "" {
+		t.Fatal("expected an idempotency key header")
+	}
+	if got := doer.requests[1].Header.Get(IdempotencyKeyHeader); got != first {
+		t.Fatalf("expected the same event to reuse key %q, got %q", first, got)
+	}
+	if got := doer.requests[2].Header.Get(IdempotencyKeyHeader); got == first {
+		t.Fatalf("expected a different event to get a different key, got %q", got)
+	}
+}
+
+func TestSenderOnResult(t *testing.T) {
+	type result struct {
+		destination string
+		err         error
+	}
+	var results []result
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer)
+	s.Retry = fastRetry(3)
+	s.OnResult = func(event Event, destination string, err error) {
+		results = append(results, result{destination, err})
+	}
+
+	if err := s.SendSlack(context.Background(), "https://hooks.slack.com/services/T/B/X", testEvent()); err != nil {
+		t.Fatalf("SendSlack returned error: %v", err)
+	}
+	doer.status = http.StatusServiceUnavailable
+	err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent())
+	if err == nil {
+		t.Fatal("expected the webhook send to fail")
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected one result per send, got %d", len(results))
+	}
+	if results[0].destination != "slack" || results[0].err != nil {
+		t.Fatalf("expected a nil slack result, got %+v", results[0])
+	}
+	if results[1].destination != "webhook" || results[1].err != err {
+		t.Fatalf("expected the final webhook error, got %+v", results[1])
+	}
+}
+
+func TestSenderOnResultPanicIsRecovered(t *testing.T) {
+	s := NewSender(&fakeDoer{status: http.StatusOK})
+	s.OnResult = func(Event, string, error) { panic("dashboard down") }
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("expected the send to succeed despite the callback, got %v", err)
+	}
+}
+
+func TestRequestHookSetsDynamicHeader(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tokens := 0
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.RequestHook = func(req *http.Request) error {
+		tokens++
+		req.Header.Set("Authorization", "Bearer token-"+strconv.Itoa(tokens))
+		return nil
+	}
+	for range 2 {
+		if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+			t.Fatalf("SendWebhook returned error: %v", err)
+		}
+	}
+	if gotAuth != "Bearer token-2" {
+		t.Fatalf("expected refreshed Authorization header, got %q", gotAuth)
+	}
+}
+
+func TestRequestHookErrorAbortsSend(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	hookErr := errors.New("token source unavailable")
+	s := NewSender(doer)
+	s.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	s.RequestHook = func(*http.Request) error { return hookErr }
+
+	err := s.SendSlack(context.Background(), "https://example.com/hook", testEvent())
+	if !errors.Is(err, hookErr) {
+		t.Fatalf("expected hook error, got %v", err)
+	}
+	if len(doer.requests) != 0 {
+		t.Fatalf("expected no request after hook error, got %d", len(doer.requests))
+	}
+}
+
+func TestEventFieldsRoundTripIntoWebhook(t *testing.T) {
+	event := testEvent()
+	event.Fields = map[string]string{"ci_job_id": "4821", "pr": "117"}
+	body, err := json.Marshal(BuildWebhookPayload(event))
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	var got struct {
+		Repository string            `json:"repository"`
+		Extra      map[string]string `json:"extra"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if got.Extra["ci_job_id"] != "4821" || got.Extra["pr"] != "117" || got.Repository != "acme/tripwire" {
+		t.Fatalf("expected custom fields under extra, got %s", body)
+	}
+
+	detail := BuildSlackPayload(event).Blocks[1].Text.Text
+	if !strings.Contains(detail, "*ci_job_id:* `4821`\n*pr:* `117`") {
+		t.Fatalf("expected sorted custom fields in the Slack detail, got %q", detail)
+	}
+}
+
+func TestEventValidateRejectsReservedFields(t *testing.T) {
+	for _, key := range []string{"repository", "Commit_SHA", "extra", " "} {
+		event := testEvent()
+		event.Fields = map[string]string{key: "x"}
+		if err := event.Validate(); err == nil {
+			t.Errorf("expected fields key %q to be rejected", key)
+		}
+	}
 }
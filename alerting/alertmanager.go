@@ -0,0 +1,109 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	AlertmanagerAlertName       = "TripwireSecretDetected"
+	DefaultAlertmanagerSeverity = "critical"
+	DefaultCommitURLBase        = "https://github.com"
+)
+
+// AlertmanagerAlert is one entry of a POST /api/v2/alerts request.
+type AlertmanagerAlert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     string            `json:"startsAt,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// AlertmanagerSender posts findings to a Prometheus Alertmanager so they
+// flow through its routing, grouping, and silences.
+type AlertmanagerSender struct {
+	Sender *Sender
+	// Endpoint is the Alertmanager base URL, e.g. http://alertmanager:9093.
+	Endpoint string
+	// Severity labels events that carry no severity of their own; empty
+	// uses DefaultAlertmanagerSeverity.
+	Severity string
+	// CommitURLBase prefixes "<repository>/commit/<sha>" in generatorURL;
+	// empty uses DefaultCommitURLBase.
+	CommitURLBase string
+}
+
+func NewAlertmanagerSender(sender *Sender, endpoint string) *AlertmanagerSender {
+	return &AlertmanagerSender{Sender: sender, Endpoint: endpoint}
+}
+
+// BuildAlertmanagerAlerts renders a finding as an Alertmanager alert. The
+// repository, rule and file path labels are the finding's identity, so
+// Alertmanager folds repeat detections into the same alert.
+func (a *AlertmanagerSender) BuildAlertmanagerAlerts(event Event) []AlertmanagerAlert {
+	severity := a.Severity
+	if event.Severity != SeverityUnset {
+		severity = event.Severity.String()
+	}
+	if severity == "" {
+		severity = DefaultAlertmanagerSeverity
+	}
+	base := a.CommitURLBase
+	if base == "" {
+		base = DefaultCommitURLBase
+	}
+
+	return []AlertmanagerAlert{{
+		Labels: map[string]string{
+			"alertname":  AlertmanagerAlertName,
+			"repository": event.Repository,
+			"rule":       event.Rule,
+			"file_path":  event.FilePath,
+			"severity":   severity,
+		},
+		Annotations: map[string]string{
+			"summary":     summaryLine(event),
+			"description": fmt.Sprintf("Rule %s matched %s on branch %s at commit %s (author %s).", event.Rule, event.FilePath, event.Branch, event.CommitSHA, event.Author),
+		},
+		StartsAt:     event.DetectedAt.UTC().Format(time.RFC3339),
+		GeneratorURL: strings.TrimRight(base, "/") + "/" + event.Repository + "/commit/" + event.CommitSHA,
+	}}
+}
+
+func (a *AlertmanagerSender) Send(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	if strings.TrimSpace(a.Endpoint) == "" {
+		return errors.New("alertmanager endpoint is required")
+	}
+	sender := senderOrDefault(a.Sender)
+	event = sender.prepareEvent(event)
+
+	err := sender.sendJSON(ctx, "alertmanager", strings.TrimRight(a.Endpoint, "/")+"/api/v2/alerts", &event, a.BuildAlertmanagerAlerts(event))
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("alertmanager rejected alert: %s: %w", alertmanagerErrorMessage(statusErr.body), err)
+	}
+	return err
+}
+
+// alertmanagerErrorMessage extracts the reason from a 400 response, which
+// is either a JSON error object or plain text.
+func alertmanagerErrorMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	if msg := strings.TrimSpace(string(body)); msg != "" {
+		return msg
+	}
+	return "no details"
+}
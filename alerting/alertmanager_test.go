@@ -0,0 +1,90 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAlertmanagerSenderPostsAlert(t *testing.T) {
+	var got []AlertmanagerAlert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/alerts" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode alerts: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	am := NewAlertmanagerSender(NewSender(srv.Client(), WithAllowInsecure()), srv.URL)
+	am.Severity = "warning"
+	event := testEvent()
+	if err := am.Send(context.Background(), event); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected one alert, got %d", len(got))
+	}
+	alert := got[0]
+	wantLabels := map[string]string{
+		"alertname":  AlertmanagerAlertName,
+		"repository": event.Repository,
+		"rule":       event.Rule,
+		"file_path":  event.FilePath,
+		"severity":   "warning",
+	}
+	for name, want := range wantLabels {
+		if alert.Labels[name] != want {
+			t.Errorf("label %s = %q, want %q", name, alert.Labels[name], want)
+		}
+	}
+	if alert.Annotations["summary"] != summaryLine(event) {
+		t.Errorf("unexpected summary %q", alert.Annotations["summary"])
+	}
+	if !strings.Contains(alert.Annotations["description"], event.FilePath) {
+		t.Errorf("unexpected description %q", alert.Annotations["description"])
+	}
+	if want := "https://github.com/acme/tripwire/commit/" + event.CommitSHA; alert.GeneratorURL != want {
+		t.Errorf("generatorURL = %q, want %q", alert.GeneratorURL, want)
+	}
+}
+
+func TestAlertmanagerSenderDefaultsSeverity(t *testing.T) {
+	alerts := NewAlertmanagerSender(nil, "http://alertmanager:9093").BuildAlertmanagerAlerts(testEvent())
+	if alerts[0].Labels["severity"] != DefaultAlertmanagerSeverity {
+		t.Fatalf("unexpected severity %q", alerts[0].Labels["severity"])
+	}
+}
+
+func TestAlertmanagerSenderSurfacesBadRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":400,"message":"start time must be before end time"}`))
+	}))
+	defer srv.Close()
+
+	err := NewAlertmanagerSender(NewSender(srv.Client(), WithAllowInsecure()), srv.URL).Send(context.Background(), testEvent())
+	if err == nil || !strings.Contains(err.Error(), "start time must be before end time") {
+		t.Fatalf("expected Alertmanager's message, got %v", err)
+	}
+	if responseStatus(err) != http.StatusBadRequest {
+		t.Fatalf("expected status 400 to be preserved, got %d", responseStatus(err))
+	}
+}
+
+func TestAlertmanagerSenderUsesEventSeverity(t *testing.T) {
+	event := testEvent()
+	event.Severity = SeverityLow
+	am := NewAlertmanagerSender(nil, "http://alertmanager:9093")
+	am.Severity = "warning"
+	if got := am.BuildAlertmanagerAlerts(event)[0].Labels["severity"]; got != "low" {
+		t.Fatalf("expected event severity label, got %q", got)
+	}
+}
@@ -0,0 +1,102 @@
+package alerting
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// AllowRule matches known-benign findings. Empty fields match anything, and
+// every non-empty field must match for the rule to apply.
+type AllowRule struct {
+	Repository string `json:"repository,omitempty"`
+	// Path is an exact file path or a glob in path.Match syntax, where a
+	// "**" segment also matches any number of directories, e.g.
+	// "testdata/**" or "**/*_test.go".
+	Path      string `json:"path,omitempty"`
+	Rule      string `json:"rule,omitempty"`
+	CommitSHA string `json:"commit_sha,omitempty"`
+}
+
+func (r AllowRule) matches(event Event) bool {
+	if r == (AllowRule{}) {
+		return false
+	}
+	if r.Repository != "" && r.Repository != event.Repository {
+		return false
+	}
+	if r.Rule != "" && r.Rule != event.Rule {
+		return false
+	}
+	if r.CommitSHA != "" && r.CommitSHA != event.CommitSHA {
+		return false
+	}
+	return r.Path == "" || MatchPath(r.Path, event.FilePath)
+}
+
+// Allowlist suppresses findings matching any of its rules. Set it on
+// Sender.Allowlist to skip suppressed events before they are sent.
+type Allowlist struct {
+	Rules []AllowRule `json:"rules"`
+}
+
+func NewAllowlist(rules ...AllowRule) *Allowlist {
+	return &Allowlist{Rules: rules}
+}
+
+// Validate reports the first rule whose path glob is malformed.
+func (a *Allowlist) Validate() error {
+	for i, r := range a.Rules {
+		if r.Path == "" {
+			continue
+		}
+		for _, segment := range strings.Split(r.Path, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("allowlist rule %d: path %q: %w", i, r.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ShouldSuppress reports whether event matches an allowlist rule. A nil
+// Allowlist suppresses nothing.
+func (a *Allowlist) ShouldSuppress(event Event) bool {
+	if a == nil {
+		return false
+	}
+	for _, r := range a.Rules {
+		if r.matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPath matches a slash-separated path against pattern segment by
+// segment in path.Match syntax, letting a "**" segment consume zero or more
+// segments. Malformed patterns match nothing.
+func MatchPath(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := len(name); i >= 0; i-- {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,73 @@
+package alerting
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestAllowlistShouldSuppress(t *testing.T) {
+	a := NewAllowlist(
+		AllowRule{Path: "testdata/**"},
+		AllowRule{Repository: "acme/tripwire", Rule: "example-key"},
+	)
+
+	fixture := testEvent()
+	fixture.FilePath = "testdata/keys/aws.txt"
+	if !a.ShouldSuppress(fixture) {
+		t.Fatal("expected a testdata/** path to be suppressed")
+	}
+
+	example := testEvent()
+	example.Rule = "example-key"
+	if !a.ShouldSuppress(example) {
+		t.Fatal("expected a matching rule to be suppressed")
+	}
+
+	if a.ShouldSuppress(testEvent()) {
+		t.Fatal("expected a non-matching event to pass through")
+	}
+	var none *Allowlist
+	if none.ShouldSuppress(testEvent()) {
+		t.Fatal("expected a nil allowlist to suppress nothing")
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"config/settings.py", "config/settings.py", true},
+		{"config/*.py", "config/settings.py", true},
+		{"config/*.py", "config/nested/settings.py", false},
+		{"**/*_test.go", "alerting/alerting_test.go", true},
+		{"**/*_test.go", "main_test.go", true},
+		{"testdata/**", "testdata", true},
+		{"docs/**/example.env", "docs/a/b/example.env", true},
+		{"[", "[", false},
+	}
+	for _, tt := range tests {
+		if got := MatchPath(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAllowlistValidate(t *testing.T) {
+	if err := NewAllowlist(AllowRule{Path: "testdata/["}).Validate(); err == nil {
+		t.Fatal("expected an error for a malformed glob")
+	}
+}
+
+func TestSenderSkipsSuppressedEvents(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer)
+	s.Allowlist = NewAllowlist(AllowRule{Rule: "aws-access-key-id"})
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if len(doer.requests) != 0 {
+		t.Fatalf("expected a suppressed event not to be sent, got %d requests", len(doer.requests))
+	}
+}
@@ -0,0 +1,218 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	DefaultAsyncQueueSize = 256
+	DefaultAsyncWorkers   = 4
+)
+
+var (
+	// ErrQueueFull is returned by Enqueue when the queue has no room.
+	ErrQueueFull = errors.New("alert queue is full")
+	// ErrSenderClosed is returned by Enqueue after Close.
+	ErrSenderClosed = errors.New("alert sender is closed")
+)
+
+// Destination delivers one event, for example a Sender method bound to a
+// webhook URL.
+type Destination func(ctx context.Context, event Event) error
+
+type asyncJob struct {
+	event       Event
+	destination Destination
+}
+
+// asyncQueue is a bounded queue with one FIFO lane per severity. Jobs
+// leave from the most severe non-empty lane; when the queue is full, the
+// newest job in the least severe lane makes room for a more severe one.
+type asyncQueue struct {
+	lanes [SeverityCritical + 1][]asyncJob
+	len   int
+	cap   int
+}
+
+// lane maps sev to its lane, treating unset and out-of-range severities as
+// DefaultSeverity.
+func lane(sev Severity) Severity {
+	if sev < SeverityLow || sev > SeverityCritical {
+		return DefaultSeverity
+	}
+	return sev
+}
+
+// push queues job, reporting whether it was admitted and whether a less
+// severe job was evicted to admit it.
+func (q *asyncQueue) push(job asyncJob) (admitted, evicted bool) {
+	sev := lane(job.event.Severity)
+	if q.len >= q.cap {
+		low := q.lowest()
+		if low >= sev {
+			return false, false
+		}
+		q.lanes[low] = q.lanes[low][:len(q.lanes[low])-1]
+		q.len--
+		evicted = true
+	}
+	q.lanes[sev] = append(q.lanes[sev], job)
+	q.len++
+	return true, evicted
+}
+
+// pop removes the oldest job of the highest severity. The queue must not
+// be empty.
+func (q *asyncQueue) pop() asyncJob {
+	for sev := SeverityCritical; ; sev-- {
+		if jobs := q.lanes[sev]; len(jobs) > 0 {
+			job := jobs[0]
+			jobs[0] = asyncJob{}
+			q.lanes[sev] = jobs[1:]
+			q.len--
+			return job
+		}
+	}
+}
+
+// lowest returns the least severe non-empty lane. The queue must not be
+// empty.
+func (q *asyncQueue) lowest() Severity {
+	sev := SeverityLow
+	for len(q.lanes[sev]) == 0 {
+		sev++
+	}
+	return sev
+}
+
+// AsyncSender delivers events on a pool of background workers so callers
+// never block on HTTP. Queued events are delivered most severe first, and
+// a full queue sheds its least severe events to admit more severe ones.
+// Failures are reported through OnError.
+type AsyncSender struct {
+	// OnError is called from a worker for every failed delivery.
+	OnError func(event Event, err error)
+
+	dropped atomic.Uint64
+	evicted atomic.Uint64
+	wg      sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	mu     sync.Mutex
+	ready  *sync.Cond
+	queue  asyncQueue
+	closed bool
+}
+
+// NewAsyncSender starts workers goroutines draining a queue of queueSize
+// events. Non-positive sizes use the Default* values.
+func NewAsyncSender(queueSize, workers int, onError func(event Event, err error)) *AsyncSender {
+	ctx, cancel := context.WithCancel(context.Background())
+	a := &AsyncSender{
+		OnError: onError,
+		queue:   asyncQueue{cap: orDefaultInt(queueSize, DefaultAsyncQueueSize)},
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	a.ready = sync.NewCond(&a.mu)
+	for range orDefaultInt(workers, DefaultAsyncWorkers) {
+		a.wg.Add(1)
+		go a.work()
+	}
+	return a
+}
+
+// Enqueue queues event for delivery to destination without blocking. When
+// the queue is full, the newest queued event of the lowest severity is
+// evicted if event is more severe; otherwise Enqueue returns ErrQueueFull
+// and counts event as dropped. Unset severities queue as DefaultSeverity.
+func (a *AsyncSender) Enqueue(event Event, destination Destination) error {
+	if destination == nil {
+		return errors.New("destination is required")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return ErrSenderClosed
+	}
+	admitted, evicted := a.queue.push(asyncJob{event: event, destination: destination})
+	if evicted {
+		a.evicted.Add(1)
+	}
+	if !admitted {
+		a.dropped.Add(1)
+		return ErrQueueFull
+	}
+	a.ready.Signal()
+	return nil
+}
+
+// Dropped returns how many events Enqueue rejected because the queue was
+// full.
+func (a *AsyncSender) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Evicted returns how many queued events were shed to admit a more severe
+// one. Evicted events are never delivered or reported to OnError.
+func (a *AsyncSender) Evicted() uint64 {
+	return a.evicted.Load()
+}
+
+// Close stops accepting events and waits for queued ones to be delivered.
+// If ctx ends first, in-flight deliveries are cancelled, the rest of the
+// queue is abandoned, and ctx's error is returned.
+func (a *AsyncSender) Close(ctx context.Context) error {
+	a.mu.Lock()
+	a.closed = true
+	a.ready.Broadcast()
+	a.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		a.cancel()
+		return nil
+	case <-ctx.Done():
+		a.cancel()
+		return ctx.Err()
+	}
+}
+
+func (a *AsyncSender) work() {
+	defer a.wg.Done()
+	for {
+		job, ok := a.next()
+		if !ok {
+			return
+		}
+		if a.ctx.Err() != nil {
+			continue
+		}
+		if err := job.destination(a.ctx, job.event); err != nil && a.OnError != nil {
+			a.OnError(job.event, err)
+		}
+	}
+}
+
+// next blocks for the next job, reporting false once the sender is closed
+// and the queue is drained.
+func (a *AsyncSender) next() (asyncJob, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for a.queue.len == 0 {
+		if a.closed {
+			return asyncJob{}, false
+		}
+		a.ready.Wait()
+	}
+	return a.queue.pop(), true
+}
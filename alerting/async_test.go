@@ -0,0 +1,154 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncSenderRejectsWhenQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	blocking := func(ctx context.Context, event Event) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	a := NewAsyncSender(1, 1, nil)
+	if err := a.Enqueue(testEvent(), blocking); err != nil {
+		t.Fatalf("first Enqueue returned error: %v", err)
+	}
+	<-started // the worker holds the first job; the queue is empty again
+	if err := a.Enqueue(testEvent(), blocking); err != nil {
+		t.Fatalf("second Enqueue returned error: %v", err)
+	}
+	if err := a.Enqueue(testEvent(), blocking); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+	if a.Dropped() != 1 {
+		t.Fatalf("expected 1 dropped event, got %d", a.Dropped())
+	}
+
+	close(release)
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+}
+
+func TestAsyncSenderCloseDrainsQueue(t *testing.T) {
+	var delivered atomic.Int32
+	var mu sync.Mutex
+	var failures []error
+	a := NewAsyncSender(16, 2, func(event Event, err error) {
+		mu.Lock()
+		failures = append(failures, err)
+		mu.Unlock()
+	})
+
+	slow := func(ctx context.Context, event Event) error {
+		time.Sleep(5 * time.Millisecond)
+		delivered.Add(1)
+		return nil
+	}
+	failing := func(ctx context.Context, event Event) error {
+		return errors.New("webhook down")
+	}
+	for range 10 {
+		if err := a.Enqueue(testEvent(), slow); err != nil {
+			t.Fatalf("Enqueue returned error: %v", err)
+		}
+	}
+	if err := a.Enqueue(testEvent(), failing); err != nil {
+		t.Fatalf("Enqueue returned error: %v", err)
+	}
+
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if got := delivered.Load(); got != 10 {
+		t.Fatalf("expected all 10 queued events delivered, got %d", got)
+	}
+	if len(failures) != 1 {
+		t.Fatalf("expected 1 reported failure, got %d", len(failures))
+	}
+	if err := a.Enqueue(testEvent(), slow); !errors.Is(err, ErrSenderClosed) {
+		t.Fatalf("expected ErrSenderClosed after Close, got %v", err)
+	}
+}
+
+func TestAsyncSenderCloseHonorsContext(t *testing.T) {
+	a := NewAsyncSender(1, 1, nil)
+	stuck := func(ctx context.Context, event Event) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if err := a.Enqueue(testEvent(), stuck); err != nil {
+		t.Fatalf("Enqueue returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := a.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestAsyncSenderShedsLowSeverityForCritical(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var mu sync.Mutex
+	var order []Severity
+	record := func(ctx context.Context, event Event) error {
+		mu.Lock()
+		order = append(order, event.Severity)
+		mu.Unlock()
+		return nil
+	}
+	blocking := func(ctx context.Context, event Event) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	a := NewAsyncSender(3, 1, nil)
+	if err := a.Enqueue(testEvent(), blocking); err != nil {
+		t.Fatalf("Enqueue returned error: %v", err)
+	}
+	<-started
+	low := testEvent()
+	low.Severity = SeverityLow
+	for range 3 {
+		if err := a.Enqueue(low, record); err != nil {
+			t.Fatalf("Enqueue returned error: %v", err)
+		}
+	}
+	if err := a.Enqueue(low, record); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected a Low event to be rejected by a queue of Lows, got %v", err)
+	}
+	critical := testEvent()
+	critical.Severity = SeverityCritical
+	if err := a.Enqueue(critical, record); err != nil {
+		t.Fatalf("expected the Critical event to be admitted, got %v", err)
+	}
+	if a.Evicted() != 1 || a.Dropped() != 1 {
+		t.Fatalf("expected 1 eviction and 1 drop, got %d and %d", a.Evicted(), a.Dropped())
+	}
+
+	close(release)
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	want := []Severity{SeverityCritical, SeverityLow, SeverityLow}
+	if len(order) != len(want) {
+		t.Fatalf("expected deliveries %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected deliveries %v, got %v", want, order)
+		}
+	}
+}
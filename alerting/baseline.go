@@ -0,0 +1,67 @@
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// Baseline holds the fingerprints of findings that were already triaged.
+// Set it on Sender.Baseline to skip them before they are sent. On disk a
+// baseline is a JSON array of Event.Fingerprint values.
+type Baseline struct {
+	fingerprints map[string]struct{}
+}
+
+// LoadBaseline reads a baseline file.
+func LoadBaseline(r io.Reader) (*Baseline, error) {
+	var fingerprints []string
+	if err := json.NewDecoder(r).Decode(&fingerprints); err != nil {
+		return nil, fmt.Errorf("decode baseline: %w", err)
+	}
+	b := &Baseline{fingerprints: make(map[string]struct{}, len(fingerprints))}
+	for _, fp := range fingerprints {
+		b.fingerprints[fp] = struct{}{}
+	}
+	return b, nil
+}
+
+// Contains reports whether event's fingerprint is in the baseline. A nil
+// Baseline contains nothing.
+func (b *Baseline) Contains(event Event) bool {
+	if b == nil {
+		return false
+	}
+	_, ok := b.fingerprints[event.Fingerprint()]
+	return ok
+}
+
+// Len returns the number of fingerprints in the baseline.
+func (b *Baseline) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.fingerprints)
+}
+
+// Write writes a baseline holding b's fingerprints plus those of events,
+// sorted so regenerated files diff cleanly. A nil b writes events alone.
+func (b *Baseline) Write(w io.Writer, events []Event) error {
+	set := make(map[string]struct{}, b.Len()+len(events))
+	if b != nil {
+		maps.Copy(set, b.fingerprints)
+	}
+	for _, event := range events {
+		set[event.Fingerprint()] = struct{}{}
+	}
+	fingerprints := slices.Sorted(maps.Keys(set))
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(fingerprints); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestBaselineSuppressesTriagedFindings(t *testing.T) {
+	triaged := []Event{testEvent(), testEvent()}
+	triaged[1].Rule = "github-token"
+
+	var buf bytes.Buffer
+	var empty *Baseline
+	if err := empty.Write(&buf, triaged); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	var fingerprints []string
+	if err := json.Unmarshal(buf.Bytes(), &fingerprints); err != nil {
+		t.Fatalf("baseline is not a JSON array: %v", err)
+	}
+	if len(fingerprints) != 2 || fingerprints[0] > fingerprints[1] {
+		t.Fatalf("expected 2 sorted fingerprints, got %v", fingerprints)
+	}
+
+	baseline, err := LoadBaseline(&buf)
+	if err != nil {
+		t.Fatalf("LoadBaseline returned error: %v", err)
+	}
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer)
+	s.Baseline = baseline
+
+	fresh := testEvent()
+	fresh.FilePath = "deploy/prod.env"
+	for _, event := range append(triaged, fresh) {
+		// A later commit of a triaged finding is still suppressed.
+		event.CommitSHA = "fedcba9876543210"
+		if err := s.SendWebhook(context.Background(), "https://example.com/hook", event); err != nil {
+			t.Fatalf("SendWebhook returned error: %v", err)
+		}
+	}
+	if len(doer.requests) != 1 {
+		t.Fatalf("expected only the new finding to alert, got %d requests", len(doer.requests))
+	}
+}
+
+func TestLoadBaselineRejectsInvalidJSON(t *testing.T) {
+	if _, err := LoadBaseline(bytes.NewBufferString(`{"not": "an array"}`)); err == nil {
+		t.Fatal("expected an error for a non-array baseline")
+	}
+}
@@ -0,0 +1,166 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = time.Minute
+)
+
+// ErrCircuitOpen is returned without contacting the destination while a
+// CircuitBreaker is open, or half-open with a probe already in flight.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed passes every send through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fast-fails every send until the cooldown elapses.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe through; its outcome closes or
+	// reopens the breaker.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker stops sending to a destination after Threshold consecutive
+// failures, fast-failing with ErrCircuitOpen for Cooldown before probing
+// again. It is safe for concurrent use; share one breaker per endpoint.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures that opens the
+	// breaker; non-positive uses DefaultBreakerThreshold.
+	Threshold int
+	// Cooldown is how long the breaker stays open before half-opening;
+	// non-positive uses DefaultBreakerCooldown.
+	Cooldown time.Duration
+	// Clock times the cooldown; nil uses RealClock.
+	Clock Clock
+	// OnStateChange, when set, is called after every transition so callers
+	// can alert on a tripped breaker. It runs with the breaker unlocked.
+	OnStateChange func(from, to BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// State reports the current state. An open breaker whose cooldown has
+// elapsed reports BreakerHalfOpen.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && !b.now().Before(b.openedAt.Add(orDefault(b.Cooldown, DefaultBreakerCooldown))) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Wrap returns a Destination that sends through dest while the breaker
+// allows it. Errors from a context the caller cancelled do not count as
+// destination failures.
+func (b *CircuitBreaker) Wrap(dest Destination) Destination {
+	return func(ctx context.Context, event Event) error {
+		if err := b.allow(); err != nil {
+			return err
+		}
+		err := dest(ctx, event)
+		b.record(err == nil || ctx.Err() != nil, err == nil)
+		return err
+	}
+}
+
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	var from, to BreakerState
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Before(b.openedAt.Add(orDefault(b.Cooldown, DefaultBreakerCooldown))) {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		from, to = b.setState(BreakerHalfOpen)
+		b.probing = true
+	case BreakerHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	b.mu.Unlock()
+	b.notify(from, to)
+	return nil
+}
+
+// record applies the outcome of a send. neutral outcomes, such as a
+// cancelled caller, release a half-open probe without moving the breaker.
+func (b *CircuitBreaker) record(neutral, success bool) {
+	b.mu.Lock()
+	var from, to BreakerState
+	wasProbe := b.state == BreakerHalfOpen
+	if wasProbe {
+		b.probing = false
+	}
+	switch {
+	case success:
+		b.failures = 0
+		from, to = b.setState(BreakerClosed)
+	case neutral:
+	case wasProbe:
+		b.openedAt = b.now()
+		from, to = b.setState(BreakerOpen)
+	default:
+		b.failures++
+		if b.state == BreakerClosed && b.failures >= orDefaultInt(b.Threshold, DefaultBreakerThreshold) {
+			b.openedAt = b.now()
+			from, to = b.setState(BreakerOpen)
+		}
+	}
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// setState moves the breaker to state and returns the transition, which is
+// a no-op pair when the state is unchanged. The caller holds b.mu.
+func (b *CircuitBreaker) setState(state BreakerState) (from, to BreakerState) {
+	from, b.state = b.state, state
+	if state != BreakerHalfOpen {
+		b.failures = 0
+	}
+	return from, state
+}
+
+func (b *CircuitBreaker) notify(from, to BreakerState) {
+	if from != to && b.OnStateChange != nil {
+		b.OnStateChange(from, to)
+	}
+}
+
+func (b *CircuitBreaker) now() time.Time {
+	return clockOrDefault(b.Clock).Now()
+}
@@ -0,0 +1,128 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerLifecycle(t *testing.T) {
+	clock := newFakeClock()
+	var transitions []string
+	b := NewCircuitBreaker(2, time.Minute)
+	b.Clock = clock
+	b.OnStateChange = func(from, to BreakerState) {
+		transitions = append(transitions, from.String()+"->"+to.String())
+	}
+
+	calls := 0
+	failing := true
+	send := b.Wrap(func(ctx context.Context, event Event) error {
+		calls++
+		if failing {
+			return errors.New("endpoint down")
+		}
+		return nil
+	})
+	ctx := context.Background()
+
+	for range 2 {
+		if err := send(ctx, testEvent()); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected the destination error while closed, got %v", err)
+		}
+	}
+	if got := b.State(); got != BreakerOpen {
+		t.Fatalf("expected open after 2 failures, got %s", got)
+	}
+	if err := send(ctx, testEvent()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the open breaker to skip the destination, got %d calls", calls)
+	}
+
+	clock.Advance(time.Minute)
+	if got := b.State(); got != BreakerHalfOpen {
+		t.Fatalf("expected half-open after the cooldown, got %s", got)
+	}
+	if err := send(ctx, testEvent()); err == nil {
+		t.Fatal("expected the failed probe to return its error")
+	}
+	if got := b.State(); got != BreakerOpen {
+		t.Fatalf("expected a failed probe to reopen the breaker, got %s", got)
+	}
+
+	clock.Advance(time.Minute)
+	failing = false
+	if err := send(ctx, testEvent()); err != nil {
+		t.Fatalf("expected the probe to succeed, got %v", err)
+	}
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("expected closed after a successful probe, got %s", got)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("expected transitions %v, got %v", want, transitions)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpenAllowsOneProbe(t *testing.T) {
+	clock := newFakeClock()
+	b := NewCircuitBreaker(1, time.Minute)
+	b.Clock = clock
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	failing := true
+	send := b.Wrap(func(ctx context.Context, event Event) error {
+		if failing {
+			return errors.New("endpoint down")
+		}
+		close(started)
+		<-release
+		return nil
+	})
+	_ = send(context.Background(), testEvent())
+	clock.Advance(time.Minute)
+	failing = false
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		if err := send(context.Background(), testEvent()); err != nil {
+			t.Errorf("probe returned error: %v", err)
+		}
+	})
+	<-started
+	if err := send(context.Background(), testEvent()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected a second send during the probe to fast-fail, got %v", err)
+	}
+	close(release)
+	wg.Wait()
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("expected closed after the probe, got %s", got)
+	}
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	b := NewCircuitBreaker(2, time.Minute)
+	results := []error{errors.New("down"), nil, errors.New("down")}
+	send := b.Wrap(func(ctx context.Context, event Event) error {
+		err := results[0]
+		results = results[1:]
+		return err
+	})
+	for range 3 {
+		_ = send(context.Background(), testEvent())
+	}
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("expected non-consecutive failures to keep the breaker closed, got %s", got)
+	}
+}
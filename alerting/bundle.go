@@ -0,0 +1,124 @@
+package alerting
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrMixedFindings is returned when findings passed to BuildSlackFilePayload
+// do not share a repository, branch, commit, and file.
+var ErrMixedFindings = errors.New("findings are not from the same file and commit")
+
+// BuildSlackFilePayload renders several findings from one file at one
+// commit, such as the keys in a leaked .env, as a single Slack message
+// listing each rule and line. It returns ErrMixedFindings if the events
+// differ in repository, branch, commit, or file.
+func BuildSlackFilePayload(events []Event, opts ...RenderOption) (SlackPayload, error) {
+	if len(events) == 0 {
+		return SlackPayload{}, errors.New("at least one finding is required")
+	}
+	first := events[0]
+	for _, event := range events[1:] {
+		if event.Repository != first.Repository || event.Branch != first.Branch ||
+			event.CommitSHA != first.CommitSHA || event.FilePath != first.FilePath {
+			return SlackPayload{}, ErrMixedFindings
+		}
+	}
+	if len(events) == 1 {
+		return BuildSlackPayload(first, opts...), nil
+	}
+
+	cfg := newRenderConfig(opts)
+	sorted := slices.Clone(events)
+	slices.SortStableFunc(sorted, func(a, b Event) int {
+		return cmp.Compare(a.LineNumber, b.LineNumber)
+	})
+	severity := maxSeverity(events)
+	summary := fmt.Sprintf(":rotating_light: %d secrets detected in %s on %s (%s)",
+		len(events), first.Repository, first.Branch, cfg.shortSHA(first.CommitSHA))
+
+	return SlackPayload{
+		Text: summary,
+		Blocks: []SlackBlock{
+			{
+				Type: "section",
+				Text: SlackText{Type: "mrkdwn", Text: "*Secret Leak Detected*"},
+			},
+			{
+				Type: "section",
+				Text: SlackText{Type: "mrkdwn", Text: strings.Join([]string{
+					fmt.Sprintf("*Repository:* `%s`", first.Repository),
+					fmt.Sprintf("*Branch:* `%s`", first.Branch),
+					fmt.Sprintf("*Commit:* `%s`", first.CommitSHA),
+					fmt.Sprintf("*File:* `%s`", TruncatePath(first.FilePath, cfg.slackPathLen)),
+					fmt.Sprintf("*Author:* `%s`", first.Author),
+				}, "\n")},
+			},
+			{
+				Type: "section",
+				Text: SlackText{Type: "mrkdwn", Text: fileFindingsSection(sorted)},
+			},
+		},
+		Attachments: []SlackAttachment{{
+			Color: severity.SlackColor(),
+			Blocks: []SlackBlock{{
+				Type: "section",
+				Text: SlackText{Type: "mrkdwn", Text: fmt.Sprintf("*Severity:* `%s`", severity)},
+			}},
+		}},
+	}, nil
+}
+
+// fileFindingsSection lists one rule and line per finding, within Slack's
+// section text limit.
+func fileFindingsSection(events []Event) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Findings* (%d)", len(events))
+	const reserve = 32
+	for i, event := range events {
+		line := fmt.Sprintf("\n• `%s`", event.Rule)
+		if event.LineNumber > 0 {
+			line += fmt.Sprintf(" on line %d", event.LineNumber)
+		}
+		if b.Len()+len(line) > SlackSectionTextLimit-reserve {
+			fmt.Fprintf(&b, "\n…and %d more", len(events)-i)
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// maxSeverity returns the most severe of the events' severities.
+func maxSeverity(events []Event) Severity {
+	highest := events[0].Severity.OrDefault()
+	for _, event := range events[1:] {
+		if event.Severity.AtLeast(highest) {
+			highest = event.Severity.OrDefault()
+		}
+	}
+	return highest
+}
+
+// SendSlackFile posts findings from one file and commit as a single Slack
+// message; see BuildSlackFilePayload.
+func (s *Sender) SendSlackFile(ctx context.Context, webhookURL string, events []Event) error {
+	for i, event := range events {
+		if err := event.Validate(); err != nil {
+			return fmt.Errorf("invalid event %d: %w", i, err)
+		}
+	}
+	events = slices.DeleteFunc(s.prepareEvents(slices.Clone(events)), s.suppressed)
+	if len(events) == 0 {
+		return nil
+	}
+	payload, err := BuildSlackFilePayload(events, s.RenderOptions...)
+	if err != nil {
+		return err
+	}
+	return s.sendJSON(ctx, "slack", webhookURL, nil, payload)
+}
@@ -0,0 +1,79 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sameFileEvents() []Event {
+	rules := []string{"aws-access-key-id", "github-token", "slack-webhook-url"}
+	events := make([]Event, len(rules))
+	for i, rule := range rules {
+		events[i] = testEvent()
+		events[i].FilePath = ".env"
+		events[i].Rule = rule
+		events[i].LineNumber = 3 - i
+	}
+	events[1].Severity = SeverityCritical
+	return events
+}
+
+func TestBuildSlackFilePayloadListsEachFinding(t *testing.T) {
+	payload, err := BuildSlackFilePayload(sameFileEvents())
+	if err != nil {
+		t.Fatalf("BuildSlackFilePayload returned error: %v", err)
+	}
+	if !strings.Contains(payload.Text, "3 secrets detected") {
+		t.Fatalf("unexpected summary %q", payload.Text)
+	}
+	list := payload.Blocks[2].Text.Text
+	want := "• `slack-webhook-url` on line 1\n• `github-token` on line 2\n• `aws-access-key-id` on line 3"
+	if !strings.Contains(list, want) {
+		t.Fatalf("expected findings ordered by line, got %q", list)
+	}
+	if payload.Attachments[0].Color != SeverityCritical.SlackColor() {
+		t.Fatalf("expected the highest severity color, got %q", payload.Attachments[0].Color)
+	}
+}
+
+func TestBuildSlackFilePayloadRejectsMixedFiles(t *testing.T) {
+	events := sameFileEvents()
+	events[2].FilePath = "config/settings.py"
+	if _, err := BuildSlackFilePayload(events); !errors.Is(err, ErrMixedFindings) {
+		t.Fatalf("expected ErrMixedFindings, got %v", err)
+	}
+	events = sameFileEvents()
+	events[0].CommitSHA = "fedcba9876543210"
+	if _, err := BuildSlackFilePayload(events); !errors.Is(err, ErrMixedFindings) {
+		t.Fatalf("expected ErrMixedFindings for another commit, got %v", err)
+	}
+}
+
+func TestSendSlackFileSendsOneMessage(t *testing.T) {
+	var requests int
+	var got SlackPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	if err := NewSender(srv.Client(), WithAllowInsecure()).SendSlackFile(context.Background(), srv.URL, sameFileEvents()); err != nil {
+		t.Fatalf("SendSlackFile returned error: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request, got %d", requests)
+	}
+	for _, rule := range []string{"aws-access-key-id", "github-token", "slack-webhook-url"} {
+		if !strings.Contains(got.Blocks[2].Text.Text, rule) {
+			t.Fatalf("expected %s in the message, got %q", rule, got.Blocks[2].Text.Text)
+		}
+	}
+}
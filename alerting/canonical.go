@@ -0,0 +1,25 @@
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// CanonicalJSON encodes v as compact JSON with every object's keys sorted,
+// including objects produced by custom MarshalJSON methods, and numbers
+// kept in their original text. Equal values always give identical bytes,
+// so signed bodies can be reproduced and verified byte for byte.
+func CanonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	// encoding/json sorts map keys, which puts every object in key order.
+	return json.Marshal(generic)
+}
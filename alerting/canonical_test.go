@@ -0,0 +1,79 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCanonicalJSONIsStable(t *testing.T) {
+	event := testEvent()
+	event.Fields = map[string]string{}
+	for i := range 50 {
+		event.Fields[fmt.Sprintf("key-%02d", i)] = fmt.Sprint(i)
+	}
+
+	first, err := CanonicalJSON(BuildWebhookPayload(event))
+	if err != nil {
+		t.Fatalf("CanonicalJSON returned error: %v", err)
+	}
+	for range 20 {
+		again, err := CanonicalJSON(BuildWebhookPayload(event))
+		if err != nil {
+			t.Fatalf("CanonicalJSON returned error: %v", err)
+		}
+		if !bytes.Equal(first, again) {
+			t.Fatalf("expected identical bytes:\n%s\n%s", first, again)
+		}
+	}
+}
+
+func TestCanonicalJSONSortsKeysAndKeepsNumbers(t *testing.T) {
+	got, err := CanonicalJSON(struct {
+		Zeta  string         `json:"zeta"`
+		Alpha map[string]any `json:"alpha"`
+		Big   int64          `json:"big"`
+	}{Zeta: "z", Alpha: map[string]any{"b": 1.5, "a": true}, Big: 1 << 62})
+	if err != nil {
+		t.Fatalf("CanonicalJSON returned error: %v", err)
+	}
+	want := `{"alpha":{"a":true,"b":1.5},"big":4611686018427387904,"zeta":"z"}`
+	if string(got) != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestSignedWebhookBodyIsCanonical(t *testing.T) {
+	secret := []byte("shared-secret")
+	var gotSig string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSig = r.Header.Get(SignatureHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	event := testEvent()
+	event.Fields = map[string]string{"pr": "17", "ci_job": "42", "actor": "bot"}
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.SigningSecret = secret
+	if err := s.SendWebhook(context.Background(), srv.URL, event); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+
+	want, err := CanonicalJSON(BuildWebhookPayload(event))
+	if err != nil {
+		t.Fatalf("CanonicalJSON returned error: %v", err)
+	}
+	if !bytes.Equal(gotBody, want) {
+		t.Fatalf("expected the canonical body:\n got %s\nwant %s", gotBody, want)
+	}
+	if gotSig != "sha256="+SignPayload(secret, want) {
+		t.Fatalf("expected the signature to verify against the canonical body, got %q", gotSig)
+	}
+}
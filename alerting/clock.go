@@ -0,0 +1,50 @@
+package alerting
+
+import "time"
+
+// Clock reads the current time. Components that stamp, expire, or window
+// findings take one so tests can control time; nil uses RealClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// AfterFuncClock is a Clock that also schedules callbacks. Throttle,
+// IncidentGrouper, and BatchSender open their windows through it when
+// their Clock implements it, so a fake clock closes a window by advancing;
+// other clocks fall back to time.AfterFunc.
+type AfterFuncClock interface {
+	Clock
+	// AfterFunc calls f in its own goroutine once d has elapsed and
+	// returns a function that cancels the call, reporting whether it did.
+	AfterFunc(d time.Duration, f func()) (stop func() bool)
+}
+
+// RealClock is the system clock.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time { return time.Now() }
+
+func (RealClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
+
+// ClockFunc adapts a function such as time.Now to a Clock.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time { return f() }
+
+func clockOrDefault(c Clock) Clock {
+	if c == nil {
+		return RealClock{}
+	}
+	return c
+}
+
+// afterFunc schedules f on c when it is an AfterFuncClock, and on the
+// system clock otherwise.
+func afterFunc(c Clock, d time.Duration, f func()) func() bool {
+	if timers, ok := clockOrDefault(c).(AfterFuncClock); ok {
+		return timers.AfterFunc(d, f)
+	}
+	return time.AfterFunc(d, f).Stop
+}
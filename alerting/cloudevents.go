@@ -0,0 +1,62 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	CloudEventsSpecVersion = "1.0"
+	CloudEventType         = "com.tripwire.secret.detected"
+	CloudEventSource       = "/tripwire"
+	CloudEventsContentType = "application/cloudevents+json"
+)
+
+// CloudEvent is a CloudEvents 1.0 structured-mode envelope carrying a
+// WebhookPayload as its data.
+type CloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	Type            string         `json:"type"`
+	Source          string         `json:"source"`
+	Subject         string         `json:"subject,omitempty"`
+	ID              string         `json:"id"`
+	Time            string         `json:"time"`
+	DataContentType string         `json:"datacontenttype"`
+	Data            WebhookPayload `json:"data"`
+}
+
+// BuildCloudEvent wraps the finding in a CloudEvents envelope. Events have
+// no ID of their own, so id identifies this delivery.
+func BuildCloudEvent(event Event, id string) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		Type:            CloudEventType,
+		Source:          CloudEventSource,
+		Subject:         event.Repository,
+		ID:              id,
+		Time:            event.DetectedAt.UTC().Format(time.RFC3339),
+		DataContentType: "application/json",
+		Data:            BuildWebhookPayload(event),
+	}
+}
+
+// SendCloudEvent posts the finding as a structured-mode CloudEvent.
+func (s *Sender) SendCloudEvent(ctx context.Context, webhookURL string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	id, err := newUUID()
+	if err != nil {
+		return fmt.Errorf("generate cloudevent id: %w", err)
+	}
+	return s.sendPayload(ctx, outboundRequest{
+		destination: "cloudevents",
+		method:      http.MethodPost,
+		url:         webhookURL,
+		contentType: CloudEventsContentType,
+		event:       &event,
+	}, BuildCloudEvent(event, id))
+}
@@ -0,0 +1,57 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestSendCloudEvent(t *testing.T) {
+	var envelope map[string]json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/cloudevents+json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+			t.Errorf("decode envelope: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	if err := s.SendCloudEvent(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendCloudEvent returned error: %v", err)
+	}
+
+	for _, attr := range []string{"specversion", "type", "source", "id", "time", "datacontenttype", "data"} {
+		if _, ok := envelope[attr]; !ok {
+			t.Fatalf("expected required attribute %q in envelope", attr)
+		}
+	}
+	var attrs struct {
+		SpecVersion string `json:"specversion"`
+		Type        string `json:"type"`
+		ID          string `json:"id"`
+		Time        string `json:"time"`
+	}
+	raw, _ := json.Marshal(envelope)
+	_ = json.Unmarshal(raw, &attrs)
+	if attrs.SpecVersion != "1.0" || attrs.Type != "com.tripwire.secret.detected" || attrs.Time != "2026-02-26T12:00:00Z" {
+		t.Fatalf("unexpected attributes %+v", attrs)
+	}
+	if !regexp.MustCompile(`^[0-9a-f-]{36}$`).MatchString(attrs.ID) {
+		t.Fatalf("unexpected id %q", attrs.ID)
+	}
+
+	var data WebhookPayload
+	if err := json.Unmarshal(envelope["data"], &data); err != nil {
+		t.Fatalf("decode data: %v", err)
+	}
+	if data.Rule != "aws-access-key-id" || data.Repository != "acme/tripwire" {
+		t.Fatalf("expected finding in data, got %+v", data)
+	}
+}
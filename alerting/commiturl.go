@@ -0,0 +1,83 @@
+package alerting
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GitProvider names the code host a repository lives on.
+type GitProvider string
+
+const (
+	GitHub    GitProvider = "github"
+	GitLab    GitProvider = "gitlab"
+	Bitbucket GitProvider = "bitbucket"
+)
+
+// defaultBaseURL returns the public host for p, or "" for an unknown
+// provider.
+func (p GitProvider) defaultBaseURL() string {
+	switch p {
+	case GitHub:
+		return "https://github.com"
+	case GitLab:
+		return "https://gitlab.com"
+	case Bitbucket:
+		return "https://bitbucket.org"
+	default:
+		return ""
+	}
+}
+
+// CommitURL links to the file at the event's commit on provider, anchored
+// at LineNumber when it is known. baseURL points at a self-hosted instance;
+// empty uses the provider's public host. Unknown providers return "".
+func (e Event) CommitURL(provider GitProvider, baseURL string) string {
+	base := strings.TrimRight(baseURL, "/")
+	if base == "" {
+		base = provider.defaultBaseURL()
+	}
+	if base == "" || e.Repository == "" || e.CommitSHA == "" {
+		return ""
+	}
+
+	repo := escapePath(e.Repository)
+	sha := url.PathEscape(e.CommitSHA)
+	file := escapePath(e.FilePath)
+	switch provider {
+	case GitHub:
+		return base + "/" + repo + "/blob/" + sha + "/" + file + e.lineAnchor("#L")
+	case GitLab:
+		return base + "/" + repo + "/-/blob/" + sha + "/" + file + e.lineAnchor("#L")
+	case Bitbucket:
+		return base + "/" + repo + "/src/" + sha + "/" + file + e.lineAnchor("#lines-")
+	default:
+		return ""
+	}
+}
+
+func (e Event) lineAnchor(prefix string) string {
+	if e.LineNumber <= 0 {
+		return ""
+	}
+	return prefix + strconv.Itoa(e.LineNumber)
+}
+
+// commitLink is CommitURL for the event's own provider, or "" when the
+// event does not name one.
+func (e Event) commitLink() string {
+	if e.Provider == "" {
+		return ""
+	}
+	return e.CommitURL(e.Provider, e.ProviderURL)
+}
+
+// escapePath escapes each slash-separated segment of p.
+func escapePath(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
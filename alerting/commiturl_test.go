@@ -0,0 +1,67 @@
+package alerting
+
+import "testing"
+
+func TestCommitURLProviders(t *testing.T) {
+	event := testEvent()
+	tests := []struct {
+		provider GitProvider
+		baseURL  string
+		want     string
+	}{
+		{GitHub, "", "https://github.com/acme/tripwire/blob/abc1234def5678/config/settings.py"},
+		{GitLab, "", "https://gitlab.com/acme/tripwire/-/blob/abc1234def5678/config/settings.py"},
+		{Bitbucket, "", "https://bitbucket.org/acme/tripwire/src/abc1234def5678/config/settings.py"},
+		{GitHub, "https://github.example.com/", "https://github.example.com/acme/tripwire/blob/abc1234def5678/config/settings.py"},
+		{"sourcehut", "", ""},
+	}
+	for _, tt := range tests {
+		if got := event.CommitURL(tt.provider, tt.baseURL); got != tt.want {
+			t.Errorf("CommitURL(%q, %q) = %q, want %q", tt.provider, tt.baseURL, got, tt.want)
+		}
+	}
+}
+
+func TestCommitURLEscapesPath(t *testing.T) {
+	event := testEvent()
+	event.FilePath = "docs/my notes#1.md"
+	want := "https://github.com/acme/tripwire/blob/abc1234def5678/docs/my%20notes%231.md"
+	if got := event.CommitURL(GitHub, ""); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestPayloadsIncludeCommitURLWhenProviderSet(t *testing.T) {
+	event := testEvent()
+	if got := BuildWebhookPayload(event).CommitURL; got != "" {
+		t.Fatalf("expected no commit_url without a provider, got %q", got)
+	}
+
+	event.Provider = GitLab
+	want := "https://gitlab.com/acme/tripwire/-/blob/abc1234def5678/config/settings.py"
+	if got := BuildWebhookPayload(event).CommitURL; got != want {
+		t.Fatalf("expected webhook commit_url %q, got %q", want, got)
+	}
+	payload := BuildSlackPayload(event)
+	if len(payload.Blocks) < 3 {
+		t.Fatalf("expected a link block, got %d blocks", len(payload.Blocks))
+	}
+	if got := payload.Blocks[2].Text.Text; got != "<"+want+"|View file at abc1234>" {
+		t.Fatalf("unexpected Slack link block %q", got)
+	}
+}
+
+func TestCommitURLAnchorsLine(t *testing.T) {
+	event := testEvent()
+	event.LineNumber = 42
+	tests := map[GitProvider]string{
+		GitHub:    "https://github.com/acme/tripwire/blob/abc1234def5678/config/settings.py#L42",
+		GitLab:    "https://gitlab.com/acme/tripwire/-/blob/abc1234def5678/config/settings.py#L42",
+		Bitbucket: "https://bitbucket.org/acme/tripwire/src/abc1234def5678/config/settings.py#lines-42",
+	}
+	for provider, want := range tests {
+		if got := event.CommitURL(provider, ""); got != want {
+			t.Errorf("CommitURL(%q) = %q, want %q", provider, got, want)
+		}
+	}
+}
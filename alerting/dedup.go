@@ -0,0 +1,192 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDedupTTL is how long a finding stays suppressed after it alerts.
+const DefaultDedupTTL = 24 * time.Hour
+
+// DedupStore remembers recently alerted keys. Implementations must be safe
+// for concurrent use.
+type DedupStore interface {
+	// Seen reports whether key was recorded within its TTL. When it was not,
+	// Seen records it for ttl in the same step.
+	Seen(key string, ttl time.Duration) bool
+	// Forget removes key so the next Seen call records it afresh.
+	Forget(key string)
+}
+
+// MemoryDedupStore is an in-process DedupStore.
+type MemoryDedupStore struct {
+	// Clock is used for expiry; nil uses RealClock.
+	Clock Clock
+
+	mu        sync.Mutex
+	expires   map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryDedupStore() *MemoryDedupStore {
+	return &MemoryDedupStore{}
+}
+
+func (m *MemoryDedupStore) Seen(key string, ttl time.Duration) bool {
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.expires == nil {
+		m.expires = make(map[string]time.Time)
+	}
+	if now.Sub(m.lastSweep) >= ttl {
+		m.sweep(now)
+	}
+	if expiry, ok := m.expires[key]; ok && now.Before(expiry) {
+		return true
+	}
+	m.expires[key] = now.Add(ttl)
+	return false
+}
+
+func (m *MemoryDedupStore) Forget(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.expires, key)
+}
+
+// Len returns the number of keys currently held, including any expired
+// keys not yet evicted.
+func (m *MemoryDedupStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.expires)
+}
+
+type dedupSnapshot struct {
+	Entries []dedupSnapshotEntry `json:"entries"`
+}
+
+type dedupSnapshotEntry struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+}
+
+// Snapshot writes the unexpired keys and their expiry times as JSON, to be
+// reloaded with Restore after a restart.
+func (m *MemoryDedupStore) Snapshot(w io.Writer) error {
+	now := m.now()
+	m.mu.Lock()
+	snapshot := dedupSnapshot{Entries: make([]dedupSnapshotEntry, 0, len(m.expires))}
+	for key, expiry := range m.expires {
+		if now.Before(expiry) {
+			snapshot.Entries = append(snapshot.Entries, dedupSnapshotEntry{Key: key, Expires: expiry})
+		}
+	}
+	m.mu.Unlock()
+
+	slices.SortFunc(snapshot.Entries, func(a, b dedupSnapshotEntry) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		return fmt.Errorf("write dedup snapshot: %w", err)
+	}
+	return nil
+}
+
+// Restore loads a Snapshot, skipping entries that have since expired. Keys
+// already in the store keep whichever expiry is later.
+func (m *MemoryDedupStore) Restore(r io.Reader) error {
+	var snapshot dedupSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return fmt.Errorf("read dedup snapshot: %w", err)
+	}
+
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.expires == nil {
+		m.expires = make(map[string]time.Time)
+	}
+	for _, entry := range snapshot.Entries {
+		if !now.Before(entry.Expires) {
+			continue
+		}
+		if current, ok := m.expires[entry.Key]; !ok || entry.Expires.After(current) {
+			m.expires[entry.Key] = entry.Expires
+		}
+	}
+	return nil
+}
+
+// sweep evicts expired keys. Callers must hold m.mu.
+func (m *MemoryDedupStore) sweep(now time.Time) {
+	for key, expiry := range m.expires {
+		if !now.Before(expiry) {
+			delete(m.expires, key)
+		}
+	}
+	m.lastSweep = now
+}
+
+func (m *MemoryDedupStore) now() time.Time {
+	return clockOrDefault(m.Clock).Now()
+}
+
+// Deduplicator wraps a Sender and drops a finding that already alerted the
+// same destination within TTL. A finding is identified by its Fingerprint
+// (repository, rule, file path, and line), so the same leak re-detected on
+// a later commit is suppressed. A failed send is forgotten so a retry can
+// alert.
+type Deduplicator struct {
+	Sender *Sender
+	// Store defaults to a MemoryDedupStore.
+	Store DedupStore
+	TTL   time.Duration
+
+	once sync.Once
+}
+
+func NewDeduplicator(sender *Sender, ttl time.Duration) *Deduplicator {
+	return &Deduplicator{Sender: sender, Store: NewMemoryDedupStore(), TTL: ttl}
+}
+
+func (d *Deduplicator) SendDiscord(ctx context.Context, webhookURL string, event Event) error {
+	return d.send("discord", event, func() error { return d.Sender.SendDiscord(ctx, webhookURL, event) })
+}
+
+func (d *Deduplicator) SendSlack(ctx context.Context, webhookURL string, event Event) error {
+	return d.send("slack", event, func() error { return d.Sender.SendSlack(ctx, webhookURL, event) })
+}
+
+func (d *Deduplicator) SendWebhook(ctx context.Context, webhookURL string, event Event) error {
+	return d.send("webhook", event, func() error { return d.Sender.SendWebhook(ctx, webhookURL, event) })
+}
+
+func (d *Deduplicator) send(destination string, event Event, deliver func() error) error {
+	if err := event.Validate(); err != nil {
+		// Let the Sender report the validation error.
+		return deliver()
+	}
+	d.once.Do(func() {
+		if d.Store == nil {
+			d.Store = NewMemoryDedupStore()
+		}
+	})
+	key := destination + ":" + event.Fingerprint()
+	if d.Store.Seen(key, orDefault(d.TTL, DefaultDedupTTL)) {
+		return nil
+	}
+	if err := deliver(); err != nil {
+		d.Store.Forget(key)
+		return err
+	}
+	return nil
+}
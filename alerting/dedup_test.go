@@ -0,0 +1,167 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDeduplicatorDropsRepeatWithinTTL(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	d := NewDeduplicator(NewSender(doer), time.Hour)
+
+	first := testEvent()
+	repeat := testEvent()
+	repeat.CommitSHA = "fedcba9876543210"
+	otherRule := testEvent()
+	otherRule.Rule = "github-pat"
+
+	for _, event := range []Event{first, repeat, otherRule} {
+		if err := d.SendSlack(context.Background(), "https://example.com/hook", event); err != nil {
+			t.Fatalf("SendSlack returned error: %v", err)
+		}
+	}
+	if len(doer.requests) != 2 {
+		t.Fatalf("expected 2 sends (repeat suppressed), got %d", len(doer.requests))
+	}
+
+	if err := d.SendDiscord(context.Background(), "https://example.com/hook", first); err != nil {
+		t.Fatalf("SendDiscord returned error: %v", err)
+	}
+	if len(doer.requests) != 3 {
+		t.Fatal("expected a different destination to still alert")
+	}
+}
+
+func TestDeduplicatorForgetsFailedSends(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusBadGateway}
+	d := NewDeduplicator(NewSender(doer), time.Hour)
+	if err := d.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err == nil {
+		t.Fatal("expected send error")
+	}
+	doer.status = http.StatusOK
+	if err := d.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if len(doer.requests) != 2 {
+		t.Fatalf("expected the failed finding to be retried, got %d requests", len(doer.requests))
+	}
+}
+
+func TestMemoryDedupStoreExpiresAndEvicts(t *testing.T) {
+	clock := newFakeClock()
+	store := &MemoryDedupStore{Clock: clock}
+
+	if store.Seen("a", time.Minute) {
+		t.Fatal("first sighting should not be seen")
+	}
+	if !store.Seen("a", time.Minute) {
+		t.Fatal("second sighting within TTL should be seen")
+	}
+
+	clock.Advance(2 * time.Minute)
+	if store.Seen("b", time.Minute) {
+		t.Fatal("new key should not be seen")
+	}
+	if store.Len() != 1 {
+		t.Fatalf("expected expired key to be evicted, have %d keys", store.Len())
+	}
+	if store.Seen("a", time.Minute) {
+		t.Fatal("expired key should alert again")
+	}
+}
+
+func TestMemoryDedupStoreTTLBoundary(t *testing.T) {
+	clock := newFakeClock()
+	store := &MemoryDedupStore{Clock: clock}
+
+	store.Seen("a", time.Minute)
+	clock.Advance(time.Minute - time.Nanosecond)
+	if !store.Seen("a", time.Minute) {
+		t.Fatal("expected the key to stay seen until its TTL elapses")
+	}
+	clock.Advance(time.Nanosecond)
+	if store.Seen("a", time.Minute) {
+		t.Fatal("expected the key to expire exactly at its TTL")
+	}
+}
+
+func TestDeduplicatorAlertsAgainAfterTTL(t *testing.T) {
+	clock := newFakeClock()
+	doer := &fakeDoer{status: http.StatusOK}
+	d := NewDeduplicator(NewSender(doer), time.Hour)
+	d.Store = &MemoryDedupStore{Clock: clock}
+
+	for range 2 {
+		if err := d.SendSlack(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+			t.Fatalf("SendSlack returned error: %v", err)
+		}
+	}
+	clock.Advance(time.Hour)
+	if err := d.SendSlack(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendSlack returned error: %v", err)
+	}
+	if len(doer.requests) != 2 {
+		t.Fatalf("expected one send per TTL window, got %d", len(doer.requests))
+	}
+}
+
+func TestMemoryDedupStoreConcurrentSeen(t *testing.T) {
+	store := NewMemoryDedupStore()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	fresh := 0
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !store.Seen("same", time.Hour) {
+				mu.Lock()
+				fresh++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if fresh != 1 {
+		t.Fatalf("expected exactly one fresh sighting, got %d", fresh)
+	}
+}
+
+func TestMemoryDedupStoreSnapshotRestore(t *testing.T) {
+	clock := newFakeClock()
+	store := &MemoryDedupStore{Clock: clock}
+	store.Seen("long-lived", time.Hour)
+	store.Seen("short-lived", time.Minute)
+
+	var buf bytes.Buffer
+	if err := store.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot returned error: %v", err)
+	}
+
+	// Restart ten minutes later: the short-lived key has expired.
+	clock.Advance(10 * time.Minute)
+	restored := &MemoryDedupStore{Clock: clock}
+	if err := restored.Restore(&buf); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if restored.Len() != 1 {
+		t.Fatalf("expected only the unexpired key to be restored, have %d", restored.Len())
+	}
+	if !restored.Seen("long-lived", time.Hour) {
+		t.Fatal("expected restored key to stay suppressed")
+	}
+	if restored.Seen("short-lived", time.Minute) {
+		t.Fatal("expected expired key to alert again")
+	}
+}
+
+func TestMemoryDedupStoreRestoreRejectsGarbage(t *testing.T) {
+	if err := NewMemoryDedupStore().Restore(strings.NewReader("not json")); err == nil {
+		t.Fatal("expected error for malformed snapshot")
+	}
+}
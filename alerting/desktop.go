@@ -0,0 +1,127 @@
+package alerting
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// CommandRunner runs an external command; it is swappable for tests.
+type CommandRunner func(ctx context.Context, name string, args ...string) error
+
+// DesktopNotifier shows findings through the OS notification center. It is
+// meant for local development and never fails a send: problems are logged
+// and the summary is written to Stdout instead.
+type DesktopNotifier struct {
+	GOOS     string
+	Run      CommandRunner
+	LookPath func(file string) (string, error)
+	Stdout   io.Writer
+	Logger   *log.Logger
+}
+
+func NewDesktopNotifier() *DesktopNotifier {
+	return &DesktopNotifier{}
+}
+
+func (n *DesktopNotifier) Notify(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	summary := summaryLine(event)
+
+	name, args, ok := desktopCommand(n.goos(), "Tripwire", summary)
+	if ok {
+		if _, err := n.lookPath(name); err != nil {
+			ok = false
+		}
+	}
+	if !ok {
+		n.fallback(summary)
+		return nil
+	}
+	if err := n.run(ctx, name, args...); err != nil {
+		n.logger().Printf("desktop notification failed: %v", err)
+		n.fallback(summary)
+	}
+	return nil
+}
+
+// desktopCommand returns the platform notifier invocation, or false when
+// the platform has no supported notifier.
+func desktopCommand(goos, title, message string) (string, []string, bool) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptQuote(message), appleScriptQuote(title))
+		return "osascript", []string{"-e", script}, true
+	case "linux":
+		return "notify-send", []string{title, message}, true
+	case "windows":
+		script := fmt.Sprintf(windowsToastScript, powerShellString(title), powerShellString(message))
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}, true
+	default:
+		return "", nil, false
+	}
+}
+
+const windowsToastScript = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode(%s)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode(%s)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('Tripwire').Show([Windows.UI.Notifications.ToastNotification]::new($template))`
+
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// powerShellString returns a PowerShell expression evaluating to s. The
+// text travels as base64, whose alphabet has no quote characters, so no
+// input can end the literal early; quoting by doubling ' would miss the
+// typographic quotes PowerShell also accepts as delimiters.
+func powerShellString(s string) string {
+	return "[Text.Encoding]::UTF8.GetString([Convert]::FromBase64String('" + base64.StdEncoding.EncodeToString([]byte(s)) + "'))"
+}
+
+func (n *DesktopNotifier) fallback(summary string) {
+	out := n.Stdout
+	if out == nil {
+		out = os.Stdout
+	}
+	fmt.Fprintln(out, summary)
+}
+
+func (n *DesktopNotifier) goos() string {
+	if n.GOOS != "" {
+		return n.GOOS
+	}
+	return runtime.GOOS
+}
+
+func (n *DesktopNotifier) run(ctx context.Context, name string, args ...string) error {
+	if n.Run != nil {
+		return n.Run(ctx, name, args...)
+	}
+	return exec.CommandContext(ctx, name, args...).Run()
+}
+
+func (n *DesktopNotifier) lookPath(file string) (string, error) {
+	if n.LookPath != nil {
+		return n.LookPath(file)
+	}
+	return exec.LookPath(file)
+}
+
+func (n *DesktopNotifier) logger() *log.Logger {
+	if n.Logger != nil {
+		return n.Logger
+	}
+	return log.Default()
+}
@@ -0,0 +1,99 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type recordedCommand struct {
+	name string
+	args []string
+}
+
+func TestDesktopNotifierCommands(t *testing.T) {
+	cases := []struct {
+		goos     string
+		wantName string
+		wantArg  string
+	}{
+		{goos: "darwin", wantName: "osascript", wantArg: "display notification"},
+		{goos: "linux", wantName: "notify-send", wantArg: "Secret detected in acme/tripwire"},
+		{goos: "windows", wantName: "powershell", wantArg: "ToastNotificationManager"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.goos, func(t *testing.T) {
+			var got []recordedCommand
+			n := &DesktopNotifier{
+				GOOS:     tc.goos,
+				LookPath: func(file string) (string, error) { return "/usr/bin/" + file, nil },
+				Run: func(_ context.Context, name string, args ...string) error {
+					got = append(got, recordedCommand{name: name, args: args})
+					return nil
+				},
+			}
+			if err := n.Notify(context.Background(), testEvent()); err != nil {
+				t.Fatalf("Notify returned error: %v", err)
+			}
+			if len(got) != 1 || got[0].name != tc.wantName {
+				t.Fatalf("expected one %s invocation, got %+v", tc.wantName, got)
+			}
+			if !strings.Contains(strings.Join(got[0].args, " "), tc.wantArg) {
+				t.Fatalf("expected args to contain %q, got %q", tc.wantArg, got[0].args)
+			}
+		})
+	}
+}
+
+func TestDesktopCommandWindowsCannotBreakOutOfStrings(t *testing.T) {
+	message := "Secret detected in acme/it’s'; Remove-Item -Recurse C:\\ #"
+	_, args, ok := desktopCommand("windows", "Tripwire", message)
+	if !ok {
+		t.Fatal("expected a windows command")
+	}
+	script := args[len(args)-1]
+	for _, quote := range []string{"’", "‘", "‚", "‛", "Remove-Item"} {
+		if strings.Contains(script, quote) {
+			t.Fatalf("expected %q not to appear in the script, got %s", quote, script)
+		}
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(message))
+	if !strings.Contains(script, "FromBase64String('"+encoded+"')") {
+		t.Fatalf("expected the message as a base64 literal, got %s", script)
+	}
+}
+
+func TestDesktopNotifierFallsBackToStdout(t *testing.T) {
+	var out bytes.Buffer
+	ran := false
+	n := &DesktopNotifier{
+		GOOS:     "linux",
+		Stdout:   &out,
+		LookPath: func(string) (string, error) { return "", errors.New("not found") },
+		Run: func(context.Context, string, ...string) error {
+			ran = true
+			return nil
+		},
+	}
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if ran {
+		t.Fatal("expected no command when the notifier binary is missing")
+	}
+	if !strings.Contains(out.String(), "acme/tripwire") {
+		t.Fatalf("expected summary on stdout, got %q", out.String())
+	}
+
+	out.Reset()
+	n.GOOS = "plan9"
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "acme/tripwire") {
+		t.Fatalf("expected summary on stdout for unsupported platform, got %q", out.String())
+	}
+}
@@ -0,0 +1,174 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SlackSectionTextLimit is the maximum length of a section block's text.
+	SlackSectionTextLimit = 3000
+	// SlackMaxBlocks is the maximum number of blocks in one message.
+	SlackMaxBlocks = 50
+
+	DefaultBatchSize   = 50
+	DefaultBatchWindow = time.Minute
+)
+
+// BuildSlackDigest renders many findings as one Slack message: a count
+// header followed by one section per repository.
+func BuildSlackDigest(events []Event, opts ...RenderOption) SlackPayload {
+	cfg := newRenderConfig(opts)
+
+	byRepo := map[string][]Event{}
+	for _, event := range events {
+		byRepo[event.Repository] = append(byRepo[event.Repository], event)
+	}
+	repos := make([]string, 0, len(byRepo))
+	for repo := range byRepo {
+		repos = append(repos, repo)
+	}
+	slices.Sort(repos)
+
+	summary := fmt.Sprintf(":rotating_light: %d %s detected across %d %s",
+		len(events), plural(len(events), "secret", "secrets"),
+		len(repos), plural(len(repos), "repository", "repositories"))
+	payload := SlackPayload{
+		Text: summary,
+		Blocks: []SlackBlock{{
+			Type: "section",
+			Text: SlackText{Type: "mrkdwn", Text: "*Secret Leak Digest*\n" + summary},
+		}},
+	}
+
+	// One block is the header; keep one spare for the overflow note.
+	shown := repos
+	if len(repos) > SlackMaxBlocks-1 {
+		shown = repos[:SlackMaxBlocks-2]
+	}
+	for _, repo := range shown {
+		payload.Blocks = append(payload.Blocks, SlackBlock{
+			Type: "section",
+			Text: SlackText{Type: "mrkdwn", Text: digestSection(repo, byRepo[repo], cfg)},
+		})
+	}
+	if hidden := len(repos) - len(shown); hidden > 0 {
+		payload.Blocks = append(payload.Blocks, slackContextBlock(fmt.Sprintf("_+%d more %s_", hidden, plural(hidden, "repository", "repositories"))))
+	}
+	return payload
+}
+
+// digestSection lists a repository's findings, cutting the list short so
+// the text stays within SlackSectionTextLimit.
+func digestSection(repo string, events []Event, cfg renderConfig) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s* (%d)", repo, len(events))
+
+	// Leave room for the "and N more" trailer.
+	const reserve = 32
+	for i, event := range events {
+		line := fmt.Sprintf("\n• `%s` in `%s` on `%s` (`%s`)", event.Rule, event.FilePath, event.Branch, cfg.shortSHA(event.CommitSHA))
+		if b.Len()+len(line) > SlackSectionTextLimit-reserve {
+			fmt.Fprintf(&b, "\n…and %d more", len(events)-i)
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// BatchSender buffers findings and posts them to Slack as one digest when
+// the batch fills, when Window elapses after the first buffered finding,
+// or on Flush.
+type BatchSender struct {
+	Sender     *Sender
+	WebhookURL string
+	MaxSize    int
+	Window     time.Duration
+	Logger     *log.Logger
+	// Clock times the window; nil uses RealClock.
+	Clock Clock
+
+	mu     sync.Mutex
+	buffer []Event
+	// stopTimer cancels the pending window flush; nil when none is
+	// scheduled.
+	stopTimer func() bool
+}
+
+func NewBatchSender(sender *Sender, webhookURL string) *BatchSender {
+	return &BatchSender{Sender: sender, WebhookURL: webhookURL}
+}
+
+// Add buffers an event, flushing synchronously once MaxSize is reached.
+func (b *BatchSender) Add(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+
+	b.mu.Lock()
+	b.buffer = append(b.buffer, event)
+	full := len(b.buffer) >= orDefaultInt(b.MaxSize, DefaultBatchSize)
+	if !full && b.stopTimer == nil {
+		b.stopTimer = afterFunc(b.Clock, orDefault(b.Window, DefaultBatchWindow), func() {
+			if err := b.Flush(context.Background()); err != nil {
+				b.logger().Printf("digest flush failed: %v", err)
+			}
+		})
+	}
+	b.mu.Unlock()
+
+	if full {
+		return b.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush sends everything buffered as one digest. An empty buffer is a
+// no-op.
+func (b *BatchSender) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	events := b.buffer
+	b.buffer = nil
+	if b.stopTimer != nil {
+		b.stopTimer()
+		b.stopTimer = nil
+	}
+	b.mu.Unlock()
+	if len(events) == 0 {
+		return nil
+	}
+
+	if strings.TrimSpace(b.WebhookURL) == "" {
+		return errors.New("digest webhook URL is required")
+	}
+	sender := senderOrDefault(b.Sender)
+	return sender.sendJSON(ctx, "slack", b.WebhookURL, nil, BuildSlackDigest(sender.prepareEvents(events), sender.RenderOptions...))
+}
+
+func (b *BatchSender) logger() *log.Logger {
+	if b.Logger != nil {
+		return b.Logger
+	}
+	return log.Default()
+}
+
+func orDefaultInt(n, fallback int) int {
+	if n > 0 {
+		return n
+	}
+	return fallback
+}
@@ -0,0 +1,138 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBuildSlackDigestGroupsByRepository(t *testing.T) {
+	events := []Event{testEvent(), testEvent(), testEvent()}
+	events[1].FilePath = "deploy/.env"
+	events[2].Repository = "acme/billing"
+
+	payload := BuildSlackDigest(events)
+	if payload.Text != ":rotating_light: 3 secrets detected across 2 repositories" {
+		t.Fatalf("unexpected summary %q", payload.Text)
+	}
+	if len(payload.Blocks) != 3 {
+		t.Fatalf("expected header plus 2 repository blocks, got %d", len(payload.Blocks))
+	}
+	billing, tripwire := payload.Blocks[1].Text.Text, payload.Blocks[2].Text.Text
+	if !strings.HasPrefix(billing, "*acme/billing* (1)") {
+		t.Fatalf("unexpected billing section %q", billing)
+	}
+	if !strings.HasPrefix(tripwire, "*acme/tripwire* (2)") || !strings.Contains(tripwire, "`deploy/.env`") {
+		t.Fatalf("unexpected tripwire section %q", tripwire)
+	}
+}
+
+func TestBuildSlackDigestTruncatesLongLists(t *testing.T) {
+	events := make([]Event, 200)
+	for i := range events {
+		events[i] = testEvent()
+		events[i].FilePath = fmt.Sprintf("services/component-%03d/config/credentials.yaml", i)
+	}
+
+	section := BuildSlackDigest(events).Blocks[1].Text.Text
+	if len(section) > SlackSectionTextLimit {
+		t.Fatalf("section is %d bytes, over the %d limit", len(section), SlackSectionTextLimit)
+	}
+	if !strings.Contains(section, "more") {
+		t.Fatalf("expected truncation note in %q", section[len(section)-40:])
+	}
+}
+
+func TestBuildSlackDigestCapsBlocks(t *testing.T) {
+	events := make([]Event, SlackMaxBlocks+10)
+	for i := range events {
+		events[i] = testEvent()
+		events[i].Repository = fmt.Sprintf("acme/repo-%03d", i)
+	}
+	payload := BuildSlackDigest(events)
+	if len(payload.Blocks) != SlackMaxBlocks {
+		t.Fatalf("expected %d blocks, got %d", SlackMaxBlocks, len(payload.Blocks))
+	}
+	if got := payload.Blocks[len(payload.Blocks)-1].Elements[0].(SlackText).Text; got != "_+12 more repositories_" {
+		t.Fatalf("unexpected overflow note %q", got)
+	}
+}
+
+func TestBatchSenderFlushSendsOneDigest(t *testing.T) {
+	var mu sync.Mutex
+	var got []SlackPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload SlackPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		mu.Lock()
+		got = append(got, payload)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	batch := NewBatchSender(NewSender(srv.Client(), WithAllowInsecure()), srv.URL)
+	batch.Window = time.Hour
+	for range 10 {
+		if err := batch.Add(context.Background(), testEvent()); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+	if err := batch.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if err := batch.Flush(context.Background()); err != nil {
+		t.Fatalf("empty Flush returned error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 1 {
+		t.Fatalf("expected one digest message, got %d", len(got))
+	}
+	if !strings.HasPrefix(got[0].Text, ":rotating_light: 10 secrets") {
+		t.Fatalf("unexpected digest text %q", got[0].Text)
+	}
+}
+
+func TestBatchSenderFlushesWhenFull(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	batch := NewBatchSender(NewSender(doer), "https://example.com/hook")
+	batch.MaxSize = 3
+	batch.Window = time.Hour
+	for range 3 {
+		if err := batch.Add(context.Background(), testEvent()); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+	if len(doer.requests) != 1 {
+		t.Fatalf("expected a flush at MaxSize, got %d requests", len(doer.requests))
+	}
+}
+
+func TestBatchSenderFlushesAfterWindow(t *testing.T) {
+	clock := newFakeClock()
+	doer := &fakeDoer{status: http.StatusOK}
+	batch := NewBatchSender(NewSender(doer), "https://hooks.slack.com/services/T/B/X")
+	batch.Window = time.Minute
+	batch.Clock = clock
+	if err := batch.Add(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	clock.Advance(59 * time.Second)
+	if len(doer.requests) != 0 {
+		t.Fatal("expected the digest to wait for the window")
+	}
+	clock.Advance(time.Second)
+	if len(doer.requests) != 1 {
+		t.Fatalf("expected digest to flush after the window, got %d requests", len(doer.requests))
+	}
+}
@@ -0,0 +1,77 @@
+package alerting
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DryRunRecord is one request a dry-run Sender would have sent.
+type DryRunRecord struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// DryRunRecorder is an HTTPDoer that records requests instead of sending
+// them and answers each with an empty 200 JSON response. It is safe for
+// concurrent use.
+type DryRunRecorder struct {
+	mu      sync.Mutex
+	records []DryRunRecord
+}
+
+// WithDryRun makes the Sender record every request in recorder instead of
+// sending it. Payload building, validation, and URL checks still run.
+func WithDryRun(recorder *DryRunRecorder) SenderOption {
+	return func(s *Sender) {
+		s.Doer = recorder
+	}
+}
+
+func (r *DryRunRecorder) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read dry-run body: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	r.records = append(r.records, DryRunRecord{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+	r.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+// Records returns a copy of everything recorded so far, oldest first.
+func (r *DryRunRecorder) Records() []DryRunRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]DryRunRecord, len(r.records))
+	copy(out, r.records)
+	return out
+}
+
+// Reset discards all recorded requests.
+func (r *DryRunRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
@@ -0,0 +1,53 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestDryRunRecordsBodyVerbatim(t *testing.T) {
+	recorder := &DryRunRecorder{}
+	s := NewSender(nil, WithDryRun(recorder))
+	event := testEvent()
+
+	if err := s.SendSlack(context.Background(), "https://hooks.slack.com/services/T/B/X", event); err != nil {
+		t.Fatalf("SendSlack returned error: %v", err)
+	}
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", event); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+
+	records := recorder.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].URL != "https://hooks.slack.com/services/T/B/X" {
+		t.Fatalf("unexpected slack URL %q", records[0].URL)
+	}
+	wantSlack, _ := json.Marshal(BuildSlackPayload(event))
+	if string(records[0].Body) != string(wantSlack) {
+		t.Fatalf("slack body not recorded verbatim:\n got %s\nwant %s", records[0].Body, wantSlack)
+	}
+	wantWebhook, _ := json.Marshal(BuildWebhookPayload(event))
+	if string(records[1].Body) != string(wantWebhook) {
+		t.Fatalf("webhook body not recorded verbatim:\n got %s\nwant %s", records[1].Body, wantWebhook)
+	}
+}
+
+func TestDryRunStillValidates(t *testing.T) {
+	recorder := &DryRunRecorder{}
+	s := NewSender(nil, WithDryRun(recorder))
+
+	if err := s.SendWebhook(context.Background(), "not a url", testEvent()); err == nil {
+		t.Fatal("expected invalid URL error")
+	}
+	invalid := testEvent()
+	invalid.Repository = ""
+	if err := s.SendSlack(context.Background(), "https://example.com/hook", invalid); err == nil {
+		t.Fatal("expected invalid event error")
+	}
+	if len(recorder.Records()) != 0 {
+		t.Fatal("expected nothing recorded for rejected sends")
+	}
+}
@@ -0,0 +1,204 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	DefaultElasticsearchIndexPrefix = "tripwire-findings"
+	DefaultElasticsearchBatchSize   = 100
+)
+
+// ElasticsearchSender buffers findings and indexes them through the _bulk
+// API into monthly indices such as tripwire-findings-2026.02.
+type ElasticsearchSender struct {
+	Sender *Sender
+	// Endpoint is the cluster base URL, e.g. https://es.example.com:9200.
+	Endpoint    string
+	IndexPrefix string
+	// APIKey, when set, is sent as "Authorization: ApiKey <key>".
+	APIKey string
+	// BatchSize triggers a flush from Add once that many events are
+	// buffered.
+	BatchSize int
+
+	mu     sync.Mutex
+	buffer []Event
+}
+
+func NewElasticsearchSender(sender *Sender, endpoint string) *ElasticsearchSender {
+	return &ElasticsearchSender{Sender: sender, Endpoint: endpoint}
+}
+
+// ElasticsearchItemFailure describes one document the bulk API rejected.
+type ElasticsearchItemFailure struct {
+	Index  string
+	ID     string
+	Status int
+	Type   string
+	Reason string
+}
+
+// ElasticsearchBulkError reports the documents that failed in an otherwise
+// successful bulk request.
+type ElasticsearchBulkError struct {
+	Failures []ElasticsearchItemFailure
+}
+
+func (e *ElasticsearchBulkError) Error() string {
+	parts := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		parts = append(parts, fmt.Sprintf("%s (status %d, %s: %s)", f.ID, f.Status, f.Type, f.Reason))
+	}
+	return fmt.Sprintf("elasticsearch rejected %d document(s): %s", len(e.Failures), strings.Join(parts, "; "))
+}
+
+// ElasticsearchDocumentID derives a stable document ID for a finding so a
+// re-sent event overwrites its earlier copy instead of duplicating it.
+func ElasticsearchDocumentID(event Event) string {
+	return FindingID(event)
+}
+
+// ElasticsearchIndex returns the monthly index an event is written to.
+func ElasticsearchIndex(prefix string, event Event) string {
+	if prefix == "" {
+		prefix = DefaultElasticsearchIndexPrefix
+	}
+	return prefix + "-" + event.DetectedAt.UTC().Format("2006.01")
+}
+
+// BuildElasticsearchBulkBody renders the NDJSON _bulk body: one index
+// action followed by the document for each event.
+func BuildElasticsearchBulkBody(prefix string, events []Event) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, event := range events {
+		action := map[string]map[string]string{
+			"index": {
+				"_index": ElasticsearchIndex(prefix, event),
+				"_id":    ElasticsearchDocumentID(event),
+			},
+		}
+		if err := enc.Encode(action); err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(BuildWebhookPayload(event)); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Add buffers an event, flushing when the batch is full.
+func (e *ElasticsearchSender) Add(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	e.mu.Lock()
+	e.buffer = append(e.buffer, event)
+	full := len(e.buffer) >= e.batchSize()
+	e.mu.Unlock()
+	if full {
+		return e.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush indexes every buffered event. If the request itself fails the
+// events stay buffered for the next flush; per-document rejections are
+// returned as an *ElasticsearchBulkError and not retried.
+func (e *ElasticsearchSender) Flush(ctx context.Context) error {
+	e.mu.Lock()
+	events := e.buffer
+	e.buffer = nil
+	e.mu.Unlock()
+	if len(events) == 0 {
+		return nil
+	}
+
+	err := e.send(ctx, events)
+	var bulkErr *ElasticsearchBulkError
+	if err != nil && !errors.As(err, &bulkErr) {
+		e.mu.Lock()
+		e.buffer = append(events, e.buffer...)
+		e.mu.Unlock()
+	}
+	return err
+}
+
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Index  string `json:"_index"`
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+func (e *ElasticsearchSender) send(ctx context.Context, events []Event) error {
+	if strings.TrimSpace(e.Endpoint) == "" {
+		return errors.New("elasticsearch endpoint is required")
+	}
+	sender := senderOrDefault(e.Sender)
+	body, err := BuildElasticsearchBulkBody(e.IndexPrefix, sender.prepareEvents(events))
+	if err != nil {
+		return fmt.Errorf("build bulk body: %w", err)
+	}
+	header := http.Header{}
+	if e.APIKey != "" {
+		header.Set("Authorization", "ApiKey "+e.APIKey)
+	}
+
+	var resp elasticsearchBulkResponse
+	if err := sender.sendPayload(ctx, outboundRequest{
+		destination: "elasticsearch",
+		method:      http.MethodPost,
+		url:         strings.TrimRight(e.Endpoint, "/") + "/_bulk",
+		contentType: "application/x-ndjson",
+		header:      header,
+		response:    &resp,
+	}, rawPayload(body)); err != nil {
+		return err
+	}
+	if !resp.Errors {
+		return nil
+	}
+
+	bulkErr := &ElasticsearchBulkError{}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error == nil {
+				continue
+			}
+			bulkErr.Failures = append(bulkErr.Failures, ElasticsearchItemFailure{
+				Index:  result.Index,
+				ID:     result.ID,
+				Status: result.Status,
+				Type:   result.Error.Type,
+				Reason: result.Error.Reason,
+			})
+		}
+	}
+	if len(bulkErr.Failures) == 0 {
+		return nil
+	}
+	return bulkErr
+}
+
+func (e *ElasticsearchSender) batchSize() int {
+	if e.BatchSize > 0 {
+		return e.BatchSize
+	}
+	return DefaultElasticsearchBatchSize
+}
@@ -0,0 +1,118 @@
+package alerting
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestElasticsearchFlushSendsBulkBody(t *testing.T) {
+	var body []byte
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		contentType = r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+		io.WriteString(w, `{"took":3,"errors":false,"items":[]}`)
+	}))
+	defer srv.Close()
+
+	es := NewElasticsearchSender(NewSender(srv.Client(), WithAllowInsecure()), srv.URL)
+	first := testEvent()
+	second := testEvent()
+	second.FilePath = "deploy/.env"
+	for _, event := range []Event{first, second} {
+		if err := es.Add(context.Background(), event); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+	if err := es.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	if contentType != "application/x-ndjson" {
+		t.Fatalf("unexpected content type %q", contentType)
+	}
+	if !bytes.HasSuffix(body, []byte("\n")) {
+		t.Fatal("bulk body must end with a newline")
+	}
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 NDJSON lines, got %d: %q", len(lines), body)
+	}
+
+	var action map[string]map[string]string
+	if err := json.Unmarshal([]byte(lines[2]), &action); err != nil {
+		t.Fatalf("decode action: %v", err)
+	}
+	if got := action["index"]["_index"]; got != "tripwire-findings-2026.02" {
+		t.Fatalf("unexpected index %q", got)
+	}
+	if got := action["index"]["_id"]; got != ElasticsearchDocumentID(second) {
+		t.Fatalf("unexpected document id %q", got)
+	}
+	var doc WebhookPayload
+	if err := json.Unmarshal([]byte(lines[3]), &doc); err != nil {
+		t.Fatalf("decode document: %v", err)
+	}
+	if doc.FilePath != "deploy/.env" {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+}
+
+func TestElasticsearchFlushReportsItemFailures(t *testing.T) {
+	event := testEvent()
+	id := ElasticsearchDocumentID(event)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"errors":true,"items":[{"index":{"_index":"tripwire-findings-2026.02","_id":"`+id+`","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [detected_at]"}}}]}`)
+	}))
+	defer srv.Close()
+
+	es := NewElasticsearchSender(NewSender(srv.Client(), WithAllowInsecure()), srv.URL)
+	if err := es.Add(context.Background(), event); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	err := es.Flush(context.Background())
+
+	var bulkErr *ElasticsearchBulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("expected bulk error, got %v", err)
+	}
+	if len(bulkErr.Failures) != 1 || bulkErr.Failures[0].ID != id || bulkErr.Failures[0].Status != http.StatusBadRequest {
+		t.Fatalf("unexpected failures %+v", bulkErr.Failures)
+	}
+	if !strings.Contains(err.Error(), "mapper_parsing_exception") {
+		t.Fatalf("expected failure reason in %q", err)
+	}
+}
+
+func TestElasticsearchFlushKeepsEventsOnRequestFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	es := NewElasticsearchSender(NewSender(srv.Client(), WithAllowInsecure()), srv.URL)
+	if err := es.Add(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if err := es.Flush(context.Background()); err == nil {
+		t.Fatal("expected flush error")
+	}
+	if len(es.buffer) != 1 {
+		t.Fatalf("expected event to stay buffered, got %d", len(es.buffer))
+	}
+}
@@ -0,0 +1,215 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmailConfig describes an SMTP relay and the message envelope.
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+	// UseTLS connects with implicit TLS (usually port 465). When false the
+	// connection is upgraded with STARTTLS if the server offers it.
+	UseTLS bool
+}
+
+func (c EmailConfig) Validate() error {
+	if strings.TrimSpace(c.Host) == "" {
+		return errors.New("smtp host is required")
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid smtp port %d", c.Port)
+	}
+	if strings.TrimSpace(c.From) == "" {
+		return errors.New("email from address is required")
+	}
+	if len(c.To) == 0 {
+		return errors.New("at least one email recipient is required")
+	}
+	for _, to := range c.To {
+		if strings.TrimSpace(to) == "" {
+			return errors.New("email recipients must not be empty")
+		}
+	}
+	return nil
+}
+
+// SMTPDialer opens the TCP connection to the SMTP relay. *net.Dialer
+// satisfies it.
+type SMTPDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// SendEmail delivers the finding as a multipart text and HTML email.
+func (s *Sender) SendEmail(ctx context.Context, cfg EmailConfig, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid email config: %w", err)
+	}
+	if s.suppressed(event) {
+		return nil
+	}
+	msg, err := buildEmailMessage(cfg, event)
+	if err != nil {
+		err = fmt.Errorf("build email: %w", err)
+	} else {
+		err = s.deliverEmail(ctx, cfg, msg)
+	}
+	s.report(ctx, &event, "email", err)
+	return err
+}
+
+// deliverEmail sends msg over one SMTP session.
+func (s *Sender) deliverEmail(ctx context.Context, cfg EmailConfig, msg []byte) error {
+	dialer := s.SMTPDialer
+	if dialer == nil {
+		dialer = &net.Dialer{Timeout: 10 * time.Second}
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("dial smtp: %w", err)
+	}
+	// net/smtp has no context support; closing the connection unblocks it.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	tlsConfig := &tls.Config{ServerName: cfg.Host}
+	if cfg.UseTLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if !cfg.UseTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("smtp starttls: %w", err)
+			}
+		}
+	}
+	if cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := client.Mail(cfg.From); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	for _, to := range cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp rcpt %s: %w", to, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("finish email: %w", err)
+	}
+	if err := client.Quit(); err != nil {
+		return fmt.Errorf("smtp quit: %w", err)
+	}
+	return nil
+}
+
+func buildEmailMessage(cfg EmailConfig, event Event) ([]byte, error) {
+	var boundary [12]byte
+	if _, err := rand.Read(boundary[:]); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	mw := multipart.NewWriter(&msg)
+	if err := mw.SetBoundary("tripwire-" + hex.EncodeToString(boundary[:])); err != nil {
+		return nil, err
+	}
+
+	headers := []struct{ name, value string }{
+		{"From", cfg.From},
+		{"To", strings.Join(cfg.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", summaryLine(event))},
+		{"Date", event.DetectedAt.UTC().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + mw.Boundary()},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.name, h.value)
+	}
+	msg.WriteString("\r\n")
+
+	if err := writeQuotedPrintablePart(mw, "text/plain; charset=utf-8", emailText(event)); err != nil {
+		return nil, err
+	}
+	if err := writeQuotedPrintablePart(mw, "text/html; charset=utf-8", emailHTML(event)); err != nil {
+		return nil, err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+func writeQuotedPrintablePart(mw *multipart.Writer, contentType, body string) error {
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func emailText(event Event) string {
+	var b strings.Builder
+	b.WriteString("Secret Leak Detected\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(FormatEventDetail(event, DetailPlain), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+func emailHTML(event Event) string {
+	var b strings.Builder
+	b.WriteString("<html><body><h2>Secret Leak Detected</h2>")
+	b.WriteString(FormatEventDetail(event, DetailHTML))
+	b.WriteString("</body></html>")
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package alerting
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeSMTPServer accepts one session and sends the DATA payload on the
+// returned channel.
+func fakeSMTPServer(t *testing.T) (string, int, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	data := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250-localhost")
+				reply("250 8BITMIME")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 end with .")
+				var body strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					body.WriteString(l)
+				}
+				data <- body.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	return host, portNum, data
+}
+
+func TestSendEmailDeliversMultipartMessage(t *testing.T) {
+	host, port, data := fakeSMTPServer(t)
+	cfg := EmailConfig{
+		Host: host,
+		Port: port,
+		From: "tripwire@example.com",
+		To:   []string{"security@example.com", "oncall@example.com"},
+	}
+
+	if err := NewSender(nil).SendEmail(context.Background(), cfg, testEvent()); err != nil {
+		t.Fatalf("SendEmail returned error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("decode subject: %v", err)
+	}
+	if subject != summaryLine(testEvent()) {
+		t.Fatalf("unexpected subject %q", subject)
+	}
+	if got := msg.Header.Get("To"); got != "security@example.com, oncall@example.com" {
+		t.Fatalf("unexpected To header %q", got)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type %q (%v)", mediaType, err)
+	}
+	parts := map[string]string{}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("read part body: %v", err)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[partType] = string(body)
+	}
+
+	if !strings.Contains(parts["text/plain"], "Repository: acme/tripwire") {
+		t.Fatalf("text part missing details: %q", parts["text/plain"])
+	}
+	if !strings.Contains(parts["text/html"], "<th align=\"left\">Rule</th><td><code>aws-access-key-id</code></td>") {
+		t.Fatalf("html part missing table row: %q", parts["text/html"])
+	}
+}
+
+func TestSendEmailEscapesHTML(t *testing.T) {
+	event := testEvent()
+	event.FilePath = "<script>.env"
+	if got := emailHTML(event); strings.Contains(got, "<script>") {
+		t.Fatalf("expected file path to be escaped, got %q", got)
+	}
+}
+
+type recordingSMTPDialer struct {
+	called bool
+}
+
+func (d *recordingSMTPDialer) DialContext(context.Context, string, string) (net.Conn, error) {
+	d.called = true
+	return nil, errors.New("unexpected dial")
+}
+
+func TestSendEmailRequiresRecipientsBeforeDialing(t *testing.T) {
+	dialer := &recordingSMTPDialer{}
+	sender := &Sender{SMTPDialer: dialer}
+	cfg := EmailConfig{Host: "smtp.example.com", Port: 587, From: "tripwire@example.com"}
+
+	err := sender.SendEmail(context.Background(), cfg, testEvent())
+	if err == nil || !strings.Contains(err.Error(), "recipient") {
+		t.Fatalf("expected recipient validation error, got %v", err)
+	}
+	if dialer.called {
+		t.Fatal("expected no dial when validation fails")
+	}
+}
@@ -0,0 +1,82 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	EventGridEventType   = "Tripwire.SecretDetected"
+	EventGridDataVersion = "1.0"
+)
+
+// ErrEventGridUnauthorized is returned when Event Grid rejects the access key.
+var ErrEventGridUnauthorized = errors.New("event grid rejected the access key")
+
+// EventGridEvent is one entry in an Event Grid schema publish request.
+type EventGridEvent struct {
+	ID          string         `json:"id"`
+	EventType   string         `json:"eventType"`
+	Subject     string         `json:"subject"`
+	EventTime   string         `json:"eventTime"`
+	Data        WebhookPayload `json:"data"`
+	DataVersion string         `json:"dataVersion"`
+}
+
+// EventGridSender publishes findings to an Azure Event Grid custom topic.
+type EventGridSender struct {
+	Sender    *Sender
+	Endpoint  string
+	AccessKey string
+}
+
+func NewEventGridSender(sender *Sender, endpoint, accessKey string) *EventGridSender {
+	return &EventGridSender{Sender: sender, Endpoint: endpoint, AccessKey: accessKey}
+}
+
+// BuildEventGridEvents returns the publish body for one finding. Events have
+// no ID of their own, so id identifies this delivery.
+func BuildEventGridEvents(event Event, id string) []EventGridEvent {
+	return []EventGridEvent{
+		{
+			ID:          id,
+			EventType:   EventGridEventType,
+			Subject:     event.Repository,
+			EventTime:   event.DetectedAt.UTC().Format(time.RFC3339),
+			Data:        BuildWebhookPayload(event),
+			DataVersion: EventGridDataVersion,
+		},
+	}
+}
+
+func (g *EventGridSender) Send(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	if strings.TrimSpace(g.AccessKey) == "" {
+		return errors.New("event grid access key is required")
+	}
+	sender := senderOrDefault(g.Sender)
+	event = sender.prepareEvent(event)
+	id, err := newUUID()
+	if err != nil {
+		return fmt.Errorf("generate event grid id: %w", err)
+	}
+
+	err = sender.sendPayload(ctx, outboundRequest{
+		destination: "eventgrid",
+		method:      http.MethodPost,
+		url:         g.Endpoint,
+		contentType: "application/json",
+		header:      http.Header{"Aeg-Sas-Key": []string{g.AccessKey}},
+		event:       &event,
+	}, BuildEventGridEvents(event, id))
+	if responseStatus(err) == http.StatusUnauthorized {
+		return fmt.Errorf("%w: %w", ErrEventGridUnauthorized, err)
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEventGridSenderPublishes(t *testing.T) {
+	var got []EventGridEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("aeg-sas-key"); key != "grid-key" {
+			t.Errorf("unexpected aeg-sas-key %q", key)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	g := NewEventGridSender(NewSender(srv.Client(), WithAllowInsecure()), srv.URL, "grid-key")
+	if err := g.Send(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected one event, got %d", len(got))
+	}
+	if got[0].EventType != "Tripwire.SecretDetected" || got[0].Subject != "acme/tripwire" || got[0].ID == "" {
+		t.Fatalf("unexpected event %+v", got[0])
+	}
+	if got[0].Data.Rule != "aws-access-key-id" {
+		t.Fatalf("expected finding in data, got %+v", got[0].Data)
+	}
+}
+
+func TestEventGridSenderUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	g := NewEventGridSender(NewSender(srv.Client(), WithAllowInsecure()), srv.URL, "bad-key")
+	if err := g.Send(context.Background(), testEvent()); !errors.Is(err, ErrEventGridUnauthorized) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+}
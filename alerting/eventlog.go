@@ -0,0 +1,160 @@
+package alerting
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"sync"
+)
+
+const eventLogIndexEntrySize = 8
+
+// EventLog is an append-only, file-backed log of events. Each appended
+// event gets the next monotonically increasing offset, and a sidecar index
+// of record positions lets consumers resume from a committed offset after
+// a restart.
+type EventLog struct {
+	mu    sync.Mutex
+	data  *os.File
+	index *os.File
+	next  uint64
+}
+
+// LogEntry is one event read back from an EventLog.
+type LogEntry struct {
+	Offset uint64 `json:"offset"`
+	Event  Event  `json:"event"`
+}
+
+// OpenEventLog opens or creates the log at path. The index is stored
+// alongside it at path + ".idx".
+func OpenEventLog(path string) (*EventLog, error) {
+	data, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open event log: %w", err)
+	}
+	index, err := os.OpenFile(path+".idx", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		data.Close()
+		return nil, fmt.Errorf("open event log index: %w", err)
+	}
+
+	info, err := index.Stat()
+	if err != nil {
+		data.Close()
+		index.Close()
+		return nil, fmt.Errorf("stat event log index: %w", err)
+	}
+	// A crash mid-write can leave a partial index entry; drop it.
+	complete := info.Size() - info.Size()%eventLogIndexEntrySize
+	if complete != info.Size() {
+		if err := index.Truncate(complete); err != nil {
+			data.Close()
+			index.Close()
+			return nil, fmt.Errorf("repair event log index: %w", err)
+		}
+	}
+
+	return &EventLog{
+		data:  data,
+		index: index,
+		next:  uint64(complete / eventLogIndexEntrySize),
+	}, nil
+}
+
+// Append records the event and returns its offset. The offset is only
+// assigned once both the record and its index entry are written.
+func (l *EventLog) Append(event Event) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	offset := l.next
+	line, err := json.Marshal(LogEntry{Offset: offset, Event: event})
+	if err != nil {
+		return 0, fmt.Errorf("marshal log entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	pos, err := l.data.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("seek event log: %w", err)
+	}
+	if _, err := l.data.Write(line); err != nil {
+		return 0, fmt.Errorf("write event log: %w", err)
+	}
+	if err := l.data.Sync(); err != nil {
+		return 0, fmt.Errorf("sync event log: %w", err)
+	}
+
+	var entry [eventLogIndexEntrySize]byte
+	binary.BigEndian.PutUint64(entry[:], uint64(pos))
+	if _, err := l.index.WriteAt(entry[:], int64(offset)*eventLogIndexEntrySize); err != nil {
+		return 0, fmt.Errorf("write event log index: %w", err)
+	}
+	if err := l.index.Sync(); err != nil {
+		return 0, fmt.Errorf("sync event log index: %w", err)
+	}
+
+	l.next++
+	return offset, nil
+}
+
+// NextOffset returns the offset the next appended event will receive.
+func (l *EventLog) NextOffset() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.next
+}
+
+// ReadFrom yields entries starting at offset, in order, up to the last
+// entry appended when iteration began. Iteration stops after the first
+// error.
+func (l *EventLog) ReadFrom(offset uint64) iter.Seq2[LogEntry, error] {
+	return func(yield func(LogEntry, error) bool) {
+		l.mu.Lock()
+		end := l.next
+		l.mu.Unlock()
+		if offset >= end {
+			return
+		}
+
+		positions := make([]byte, (end-offset)*eventLogIndexEntrySize)
+		if _, err := l.index.ReadAt(positions, int64(offset)*eventLogIndexEntrySize); err != nil {
+			yield(LogEntry{}, fmt.Errorf("read event log index: %w", err))
+			return
+		}
+
+		for i := range end - offset {
+			// Seek through the index for every entry so records orphaned by a
+			// crash before their index entry was written are never read.
+			pos := int64(binary.BigEndian.Uint64(positions[i*eventLogIndexEntrySize:]))
+			line, err := bufio.NewReader(io.NewSectionReader(l.data, pos, 1<<62)).ReadBytes('\n')
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = io.ErrUnexpectedEOF
+				}
+				yield(LogEntry{}, fmt.Errorf("read event log: %w", err))
+				return
+			}
+			var got LogEntry
+			if err := json.Unmarshal(line, &got); err != nil {
+				yield(LogEntry{}, fmt.Errorf("decode event log entry: %w", err))
+				return
+			}
+			if !yield(got, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (l *EventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return errors.Join(l.data.Close(), l.index.Close())
+}
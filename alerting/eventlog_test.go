@@ -0,0 +1,93 @@
+package alerting
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEventLogReadFromMidLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	log, err := OpenEventLog(path)
+	if err != nil {
+		t.Fatalf("OpenEventLog returned error: %v", err)
+	}
+	for i := range 5 {
+		event := testEvent()
+		event.FilePath = fmt.Sprintf("file-%d.env", i)
+		offset, err := log.Append(event)
+		if err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+		if offset != uint64(i) {
+			t.Fatalf("expected offset %d, got %d", i, offset)
+		}
+	}
+
+	var got []LogEntry
+	for entry, err := range log.ReadFrom(2) {
+		if err != nil {
+			t.Fatalf("ReadFrom returned error: %v", err)
+		}
+		got = append(got, entry)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 entries from offset 2, got %d", len(got))
+	}
+	for i, entry := range got {
+		if entry.Offset != uint64(i+2) || entry.Event.FilePath != fmt.Sprintf("file-%d.env", i+2) {
+			t.Fatalf("unexpected entry %d: %+v", i, entry)
+		}
+	}
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+}
+
+func TestEventLogOffsetsSurviveRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	log, err := OpenEventLog(path)
+	if err != nil {
+		t.Fatalf("OpenEventLog returned error: %v", err)
+	}
+	if _, err := log.Append(testEvent()); err != nil {
+		t.Fatalf("Append returned error: %v", err)
+	}
+	log.Close()
+
+	// Simulate a crash that wrote a record but not its index entry.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	_, _ = f.WriteString(`{"offset":1,"event":{"repository":"orphan"}}` + "\n")
+	f.Close()
+
+	log, err = OpenEventLog(path)
+	if err != nil {
+		t.Fatalf("reopen returned error: %v", err)
+	}
+	defer log.Close()
+	if next := log.NextOffset(); next != 1 {
+		t.Fatalf("expected next offset 1 after restart, got %d", next)
+	}
+	offset, err := log.Append(testEvent())
+	if err != nil || offset != 1 {
+		t.Fatalf("expected offset 1, got %d (%v)", offset, err)
+	}
+
+	count := 0
+	for entry, err := range log.ReadFrom(0) {
+		if err != nil {
+			t.Fatalf("ReadFrom returned error: %v", err)
+		}
+		if entry.Event.Repository == "orphan" {
+			t.Fatal("expected orphaned record to be skipped")
+		}
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 entries, got %d", count)
+	}
+}
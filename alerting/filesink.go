@@ -0,0 +1,62 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// FileSink appends each finding's webhook payload to a writer as one JSON
+// line, for archival and SIEM ingestion. It is safe for concurrent use.
+type FileSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewFileSink writes to w. Each line is written with a single Write call.
+func NewFileSink(w io.Writer) *FileSink {
+	return &FileSink{w: w}
+}
+
+// OpenFileSink appends to the file at path, creating it if missing. Close
+// the sink to close the file.
+func OpenFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open file sink: %w", err)
+	}
+	return &FileSink{w: f, closer: f}, nil
+}
+
+func (f *FileSink) Notify(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	line, err := json.Marshal(BuildWebhookPayload(event))
+	if err != nil {
+		return fmt.Errorf("marshal file sink line: %w", err)
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.w.Write(line); err != nil {
+		return fmt.Errorf("write file sink line: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file opened by OpenFileSink; it is a no-op for sinks
+// built with NewFileSink.
+func (f *FileSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
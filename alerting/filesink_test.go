@@ -0,0 +1,52 @@
+package alerting
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSinkAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.jsonl")
+	rules := []string{"aws-access-key-id", "github-token", "slack-token"}
+	for _, rule := range rules {
+		sink, err := OpenFileSink(path)
+		if err != nil {
+			t.Fatalf("OpenFileSink returned error: %v", err)
+		}
+		event := testEvent()
+		event.Rule = rule
+		if err := sink.Notify(context.Background(), event); err != nil {
+			t.Fatalf("Notify returned error: %v", err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatalf("Close returned error: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open sink file: %v", err)
+	}
+	defer f.Close()
+	var got []WebhookPayload
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var payload WebhookPayload
+		if err := json.Unmarshal(scanner.Bytes(), &payload); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", len(got)+1, err)
+		}
+		got = append(got, payload)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(got))
+	}
+	for i, rule := range rules {
+		if got[i].Rule != rule || got[i].Event != "secret.detected" {
+			t.Fatalf("unexpected line %d: %+v", i+1, got[i])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package alerting
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignedFindingURLRoundTrip(t *testing.T) {
+	secret := []byte("link-secret")
+	event := testEvent()
+	link, err := SignedFindingURL("https://tripwire.example.com/findings?view=share", event, time.Hour, secret)
+	if err != nil {
+		t.Fatalf("SignedFindingURL returned error: %v", err)
+	}
+	if strings.Contains(link, event.Repository) || strings.Contains(link, url.QueryEscape(event.FilePath)) {
+		t.Fatalf("link must not expose finding metadata: %s", link)
+	}
+
+	id, err := VerifySignedFindingURL(link, secret)
+	if err != nil {
+		t.Fatalf("VerifySignedFindingURL returned error: %v", err)
+	}
+	if id != FindingID(event) {
+		t.Fatalf("expected finding id %q, got %q", FindingID(event), id)
+	}
+}
+
+func TestVerifySignedFindingURLRejectsExpired(t *testing.T) {
+	secret := []byte("link-secret")
+	clock := newFakeClock()
+	link, err := signedFindingURL("https://tripwire.example.com/findings", testEvent(), time.Minute, secret, clock)
+	if err != nil {
+		t.Fatalf("SignedFindingURL returned error: %v", err)
+	}
+	clock.Advance(59 * time.Second)
+	if _, err := verifySignedFindingURL(link, secret, clock); err != nil {
+		t.Fatalf("expected the link to be valid before expiry, got %v", err)
+	}
+	clock.Advance(time.Second)
+	if _, err := verifySignedFindingURL(link, secret, clock); !errors.Is(err, ErrFindingURLExpired) {
+		t.Fatalf("expected expired error, got %v", err)
+	}
+}
+
+func TestVerifySignedFindingURLRejectsTampering(t *testing.T) {
+	secret := []byte("link-secret")
+	link, err := SignedFindingURL("https://tripwire.example.com/findings", testEvent(), time.Hour, secret)
+	if err != nil {
+		t.Fatalf("SignedFindingURL returned error: %v", err)
+	}
+	u, _ := url.Parse(link)
+
+	extended := *u
+	q := extended.Query()
+	q.Set("expires", "9999999999")
+	extended.RawQuery = q.Encode()
+
+	other := *u
+	q = other.Query()
+	q.Set("finding", FindingID(TestEvent()))
+	other.RawQuery = q.Encode()
+
+	cases := map[string]struct {
+		link   string
+		secret []byte
+	}{
+		"extended expiry": {link: extended.String(), secret: secret},
+		"swapped finding": {link: other.String(), secret: secret},
+		"wrong secret":    {link: link, secret: []byte("other-secret")},
+		"missing sig":     {link: "https://tripwire.example.com/findings?finding=x&expires=1", secret: secret},
+	}
+	for name, tc := range cases {
+		if _, err := VerifySignedFindingURL(tc.link, tc.secret); !errors.Is(err, ErrFindingURLSignature) {
+			t.Errorf("%s: expected signature error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// githubAPIBaseURL is the REST API root; tests point it at a local server.
+var githubAPIBaseURL = "https://api.github.com"
+
+// GitHubIssue is the create-issue request body.
+type GitHubIssue struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// BuildGitHubIssue renders the finding as a markdown issue labelled
+// security and tripwire.
+func BuildGitHubIssue(event Event) GitHubIssue {
+	var b strings.Builder
+	b.WriteString("## Secret Leak Detected\n\n")
+	b.WriteString(summaryLine(event) + "\n\n")
+	b.WriteString("| Field | Value |\n| --- | --- |\n")
+	for _, f := range detailFields(event) {
+		fmt.Fprintf(&b, "| %s | `%s` |\n", f.name, strings.ReplaceAll(f.value, "|", `\|`))
+	}
+	return GitHubIssue{
+		Title:  fmt.Sprintf("Secret detected: %s in %s", event.Rule, event.FilePath),
+		Body:   b.String(),
+		Labels: []string{"security", "tripwire"},
+	}
+}
+
+// SendGitHubIssue opens an issue in owner/repo, which need not be the
+// repository the finding came from.
+func (s *Sender) SendGitHubIssue(ctx context.Context, token, owner, repo string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	if strings.TrimSpace(token) == "" {
+		return errors.New("github token is required")
+	}
+	if strings.TrimSpace(owner) == "" || strings.TrimSpace(repo) == "" {
+		return errors.New("github owner and repo are required")
+	}
+
+	return s.sendPayload(ctx, outboundRequest{
+		destination: "github",
+		method:      http.MethodPost,
+		url:         githubAPIBaseURL + "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/issues",
+		contentType: "application/json",
+		header: http.Header{
+			"Authorization":        []string{"Bearer " + token},
+			"Accept":               []string{"application/vnd.github+json"},
+			"X-Github-Api-Version": []string{"2022-11-28"},
+		},
+		event: &event,
+	}, BuildGitHubIssue(event))
+}
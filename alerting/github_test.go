@@ -0,0 +1,49 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSendGitHubIssue(t *testing.T) {
+	var got GitHubIssue
+	var gotPath, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode issue: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+	previous := githubAPIBaseURL
+	githubAPIBaseURL = srv.URL
+	t.Cleanup(func() { githubAPIBaseURL = previous })
+
+	event := testEvent()
+	if err := NewSender(srv.Client(), WithAllowInsecure()).SendGitHubIssue(context.Background(), "ghp_token", "acme", "security-triage", event); err != nil {
+		t.Fatalf("SendGitHubIssue returned error: %v", err)
+	}
+
+	if gotPath != "/repos/acme/security-triage/issues" {
+		t.Fatalf("unexpected path %q", gotPath)
+	}
+	if gotAuth != "Bearer ghp_token" {
+		t.Fatalf("unexpected Authorization header %q", gotAuth)
+	}
+	if !slices.Equal(got.Labels, []string{"security", "tripwire"}) {
+		t.Fatalf("unexpected labels %v", got.Labels)
+	}
+	if got.Title != "Secret detected: aws-access-key-id in config/settings.py" {
+		t.Fatalf("unexpected title %q", got.Title)
+	}
+	if !strings.Contains(got.Body, "| Repository | `acme/tripwire` |") {
+		t.Fatalf("expected scanned repository in body, got %q", got.Body)
+	}
+}
@@ -0,0 +1,80 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+)
+
+// GoogleChatPayload is a Google Chat incoming webhook message carrying one
+// cardsV2 card.
+type GoogleChatPayload struct {
+	Text    string             `json:"text,omitempty"`
+	CardsV2 []GoogleChatCardV2 `json:"cardsV2"`
+}
+
+// GoogleChatCardV2 wraps a card with the ID Chat uses to update it.
+type GoogleChatCardV2 struct {
+	CardID string         `json:"cardId"`
+	Card   GoogleChatCard `json:"card"`
+}
+
+type GoogleChatCard struct {
+	Header   GoogleChatCardHeader `json:"header"`
+	Sections []GoogleChatSection  `json:"sections"`
+}
+
+type GoogleChatCardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type GoogleChatSection struct {
+	Header  string             `json:"header,omitempty"`
+	Widgets []GoogleChatWidget `json:"widgets"`
+}
+
+// GoogleChatWidget holds one widget; tripwire only uses decoratedText.
+type GoogleChatWidget struct {
+	DecoratedText *GoogleChatDecoratedText `json:"decoratedText,omitempty"`
+}
+
+// GoogleChatDecoratedText is a labelled value row.
+type GoogleChatDecoratedText struct {
+	TopLabel string `json:"topLabel"`
+	Text     string `json:"text"`
+}
+
+func BuildGoogleChatPayload(event Event, opts ...RenderOption) GoogleChatPayload {
+	cfg := newRenderConfig(opts)
+	fields := detailFields(event)
+	widgets := make([]GoogleChatWidget, 0, len(fields))
+	for _, f := range fields {
+		widgets = append(widgets, GoogleChatWidget{
+			DecoratedText: &GoogleChatDecoratedText{TopLabel: f.name, Text: f.value},
+		})
+	}
+
+	return GoogleChatPayload{
+		Text: "🚨 " + summaryLine(event, opts...),
+		CardsV2: []GoogleChatCardV2{
+			{
+				CardID: "tripwire-" + event.Fingerprint()[:16],
+				Card: GoogleChatCard{
+					Header: GoogleChatCardHeader{
+						Title:    "Secret Leak Detected",
+						Subtitle: fmt.Sprintf("%s on %s (%s)", event.Repository, event.Branch, cfg.shortSHA(event.CommitSHA)),
+					},
+					Sections: []GoogleChatSection{{Widgets: widgets}},
+				},
+			},
+		},
+	}
+}
+
+func (s *Sender) SendGoogleChat(ctx context.Context, webhookURL string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	return s.sendJSON(ctx, "googlechat", webhookURL, &event, BuildGoogleChatPayload(event, s.RenderOptions...))
+}
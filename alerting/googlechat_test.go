@@ -0,0 +1,54 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBuildGoogleChatPayload(t *testing.T) {
+	payload := BuildGoogleChatPayload(testEvent())
+	if len(payload.CardsV2) != 1 {
+		t.Fatalf("expected 1 card, got %d", len(payload.CardsV2))
+	}
+	header := payload.CardsV2[0].Card.Header
+	if !strings.Contains(header.Subtitle, "(abc1234)") {
+		t.Fatalf("expected short SHA in subtitle, got %q", header.Subtitle)
+	}
+}
+
+func TestSendGoogleChat(t *testing.T) {
+	var got GoogleChatPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	if err := NewSender(srv.Client(), WithAllowInsecure()).SendGoogleChat(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendGoogleChat returned error: %v", err)
+	}
+	if len(got.CardsV2) != 1 || len(got.CardsV2[0].Card.Sections) != 1 {
+		t.Fatalf("unexpected card %+v", got)
+	}
+	found := false
+	for _, w := range got.CardsV2[0].Card.Sections[0].Widgets {
+		if w.DecoratedText != nil && w.DecoratedText.TopLabel == "Rule" && w.DecoratedText.Text == "aws-access-key-id" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a Rule widget, got %+v", got.CardsV2[0].Card.Sections[0].Widgets)
+	}
+}
+
+func TestSendGoogleChatRejectsInvalidURL(t *testing.T) {
+	if err := NewSender(nil).SendGoogleChat(context.Background(), "not a url", testEvent()); err == nil {
+		t.Fatal("expected an invalid URL error")
+	}
+}
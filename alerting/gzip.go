@@ -0,0 +1,32 @@
+package alerting
+
+import (
+	"bytes"
+	"compress/gzip"
+)
+
+// DefaultGzipMinBytes is the body size above which WithGzip compresses
+// when given a non-positive threshold.
+const DefaultGzipMinBytes = 1024
+
+// WithGzip gzips request bodies larger than minBytes and sets
+// Content-Encoding: gzip; smaller bodies are sent as-is. Only enable it for
+// receivers that accept gzip. Webhook signatures cover the compressed
+// bytes, as sent on the wire.
+func WithGzip(minBytes int) SenderOption {
+	return func(s *Sender) {
+		s.gzipMinBytes = orDefaultInt(minBytes, DefaultGzipMinBytes)
+	}
+}
+
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,47 @@
+package alerting
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWithGzipCompressesLargeBodies(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer, WithGzip(1024))
+
+	large := testEvent()
+	large.Fields = map[string]string{"notes": strings.Repeat("rotate me ", 300)}
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", large); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+
+	req := doer.requests[0]
+	if got := req.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("expected Content-Encoding gzip, got %q", got)
+	}
+	zr, err := gzip.NewReader(req.Body)
+	if err != nil {
+		t.Fatalf("body is not gzip: %v", err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("gunzip body: %v", err)
+	}
+	want, _ := json.Marshal(BuildWebhookPayload(large))
+	if !bytes.Equal(body, want) {
+		t.Fatalf("expected the original JSON after gunzip, got %s", body)
+	}
+
+	if got := doer.requests[1].Header.Get("Content-Encoding"); got != "" {
+		t.Fatalf("expected a small body to stay uncompressed, got Content-Encoding %q", got)
+	}
+}
@@ -0,0 +1,133 @@
+package alerting
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultIncidentWindow is how long IncidentGrouper collects findings from
+// one commit before emitting the incident.
+const DefaultIncidentWindow = 30 * time.Second
+
+// Incident is a group of findings introduced by the same commit.
+type Incident struct {
+	ID     string  `json:"id"`
+	Events []Event `json:"events"`
+}
+
+// IncidentID returns the stable incident ID for a repository and commit.
+func IncidentID(repository, commitSHA string) string {
+	sum := sha256.Sum256([]byte(repository + "\x00" + commitSHA))
+	return "inc-" + hex.EncodeToString(sum[:8])
+}
+
+// IncidentGrouper collects findings that share (repository, commit) over
+// a short window and emits them as one Incident. Each finding is still
+// appended to Log individually when a log is configured.
+type IncidentGrouper struct {
+	Window time.Duration
+	Emit   func(ctx context.Context, incident Incident) error
+	Log    *EventLog
+	Logger *log.Logger
+	// Clock times the windows; nil uses RealClock.
+	Clock Clock
+
+	mu      sync.Mutex
+	pending map[string]*pendingIncident
+}
+
+type pendingIncident struct {
+	incident Incident
+	stop     func() bool
+}
+
+func NewIncidentGrouper(window time.Duration, emit func(ctx context.Context, incident Incident) error) *IncidentGrouper {
+	return &IncidentGrouper{Window: window, Emit: emit}
+}
+
+// Add records the finding and schedules its incident for emission once the
+// window that started with the incident's first finding elapses.
+func (g *IncidentGrouper) Add(event Event) (string, error) {
+	if err := event.Validate(); err != nil {
+		return "", fmt.Errorf("invalid event: %w", err)
+	}
+	if g.Emit == nil {
+		return "", errors.New("incident emit function is required")
+	}
+	if g.Log != nil {
+		if _, err := g.Log.Append(event); err != nil {
+			return "", fmt.Errorf("record finding: %w", err)
+		}
+	}
+
+	id := IncidentID(event.Repository, event.CommitSHA)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.pending == nil {
+		g.pending = make(map[string]*pendingIncident)
+	}
+	if p, ok := g.pending[id]; ok {
+		p.incident.Events = append(p.incident.Events, event)
+		return id, nil
+	}
+
+	window := g.Window
+	if window <= 0 {
+		window = DefaultIncidentWindow
+	}
+	g.pending[id] = &pendingIncident{
+		incident: Incident{ID: id, Events: []Event{event}},
+		stop: afterFunc(g.Clock, window, func() {
+			if err := g.emit(context.Background(), id); err != nil {
+				g.logger().Printf("incident %s emit failed: %v", id, err)
+			}
+		}),
+	}
+	return id, nil
+}
+
+// Flush emits every pending incident immediately.
+func (g *IncidentGrouper) Flush(ctx context.Context) error {
+	g.mu.Lock()
+	ids := make([]string, 0, len(g.pending))
+	for id := range g.pending {
+		ids = append(ids, id)
+	}
+	g.mu.Unlock()
+
+	var errs []error
+	for _, id := range ids {
+		if err := g.emit(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("incident %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (g *IncidentGrouper) emit(ctx context.Context, id string) error {
+	g.mu.Lock()
+	p, ok := g.pending[id]
+	if ok {
+		p.stop()
+		delete(g.pending, id)
+	}
+	g.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return g.Emit(ctx, p.incident)
+}
+
+func (g *IncidentGrouper) logger() *log.Logger {
+	if g.Logger != nil {
+		return g.Logger
+	}
+	return log.Default()
+}
@@ -0,0 +1,88 @@
+package alerting
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type incidentRecorder struct {
+	mu        sync.Mutex
+	incidents []Incident
+}
+
+func (r *incidentRecorder) emit(_ context.Context, incident Incident) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.incidents = append(r.incidents, incident)
+	return nil
+}
+
+func TestIncidentGrouperGroupsByCommit(t *testing.T) {
+	eventLog, err := OpenEventLog(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatalf("OpenEventLog returned error: %v", err)
+	}
+	defer eventLog.Close()
+
+	rec := &incidentRecorder{}
+	g := NewIncidentGrouper(time.Hour, rec.emit)
+	g.Log = eventLog
+
+	var ids []string
+	for _, rule := range []string{"aws-access-key-id", "github-token", "slack-webhook"} {
+		event := testEvent()
+		event.Rule = rule
+		id, err := g.Add(event)
+		if err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	other := testEvent()
+	other.CommitSHA = "fff0000aaa1111"
+	if _, err := g.Add(other); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	if err := g.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if ids[0] != ids[1] || ids[1] != ids[2] || ids[0] != IncidentID("acme/tripwire", "abc1234def5678") {
+		t.Fatalf("expected one stable incident id, got %v", ids)
+	}
+	if len(rec.incidents) != 2 {
+		t.Fatalf("expected 2 incidents, got %d", len(rec.incidents))
+	}
+	for _, incident := range rec.incidents {
+		if incident.ID == ids[0] && len(incident.Events) != 3 {
+			t.Fatalf("expected 3 events in the shared-commit incident, got %d", len(incident.Events))
+		}
+	}
+	if next := eventLog.NextOffset(); next != 4 {
+		t.Fatalf("expected every finding in the audit log, got %d entries", next)
+	}
+}
+
+func TestIncidentGrouperEmitsAfterWindow(t *testing.T) {
+	clock := newFakeClock()
+	var emitted []Incident
+	g := NewIncidentGrouper(time.Minute, func(_ context.Context, incident Incident) error {
+		emitted = append(emitted, incident)
+		return nil
+	})
+	g.Clock = clock
+	if _, err := g.Add(testEvent()); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	clock.Advance(30 * time.Second)
+	if len(emitted) != 0 {
+		t.Fatal("expected the incident to stay open inside the window")
+	}
+	clock.Advance(30 * time.Second)
+	if len(emitted) != 1 || len(emitted[0].Events) != 1 {
+		t.Fatalf("expected one incident with 1 event after the window, got %+v", emitted)
+	}
+}
@@ -0,0 +1,132 @@
+package alerting
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultJiraIssueType is used when JiraConfig.IssueType is empty.
+const DefaultJiraIssueType = "Bug"
+
+// JiraConfig identifies the Jira site, credentials, and where issues go.
+type JiraConfig struct {
+	// BaseURL is the site root, e.g. https://acme.atlassian.net.
+	BaseURL    string
+	Email      string
+	APIToken   string
+	ProjectKey string
+	IssueType  string
+}
+
+func (c JiraConfig) Validate() error {
+	if strings.TrimSpace(c.BaseURL) == "" {
+		return errors.New("jira base URL is required")
+	}
+	if strings.TrimSpace(c.Email) == "" || strings.TrimSpace(c.APIToken) == "" {
+		return errors.New("jira email and API token are required")
+	}
+	if strings.TrimSpace(c.ProjectKey) == "" {
+		return errors.New("jira project key is required")
+	}
+	return nil
+}
+
+// JiraIssue is the create-issue request body for the REST API v2.
+type JiraIssue struct {
+	Fields JiraIssueFields `json:"fields"`
+}
+
+type JiraIssueFields struct {
+	Project     JiraKey  `json:"project"`
+	IssueType   JiraName `json:"issuetype"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Priority    JiraName `json:"priority"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+type JiraKey struct {
+	Key string `json:"key"`
+}
+
+type JiraName struct {
+	Name string `json:"name"`
+}
+
+// JiraPriority maps a severity onto Jira's default priority scheme.
+func JiraPriority(severity Severity) string {
+	switch severity.OrDefault() {
+	case SeverityCritical:
+		return "Highest"
+	case SeverityHigh:
+		return "High"
+	case SeverityLow:
+		return "Low"
+	default:
+		return "Medium"
+	}
+}
+
+func BuildJiraIssue(cfg JiraConfig, event Event) JiraIssue {
+	issueType := cfg.IssueType
+	if issueType == "" {
+		issueType = DefaultJiraIssueType
+	}
+
+	lines := make([]string, 0, 8)
+	for _, f := range detailFields(event) {
+		lines = append(lines, fmt.Sprintf("*%s:* {{%s}}", f.name, f.value))
+	}
+	lines = append(lines, "*Severity:* "+event.Severity.OrDefault().String())
+
+	return JiraIssue{
+		Fields: JiraIssueFields{
+			Project:     JiraKey{Key: cfg.ProjectKey},
+			IssueType:   JiraName{Name: issueType},
+			Summary:     summaryLine(event),
+			Description: strings.Join(lines, "\n"),
+			Priority:    JiraName{Name: JiraPriority(event.Severity)},
+			Labels:      []string{"tripwire"},
+		},
+	}
+}
+
+// SendJira creates a Jira issue for the finding and returns its key, e.g.
+// "SEC-123".
+func (s *Sender) SendJira(ctx context.Context, cfg JiraConfig, event Event) (string, error) {
+	if err := event.Validate(); err != nil {
+		return "", fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	if err := cfg.Validate(); err != nil {
+		return "", fmt.Errorf("invalid jira config: %w", err)
+	}
+	if s.suppressed(event) {
+		return "", nil
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(cfg.Email + ":" + cfg.APIToken))
+	var created struct {
+		Key string `json:"key"`
+	}
+	err := s.sendPayload(ctx, outboundRequest{
+		destination: "jira",
+		method:      http.MethodPost,
+		url:         strings.TrimRight(cfg.BaseURL, "/") + "/rest/api/2/issue",
+		contentType: "application/json",
+		header:      http.Header{"Authorization": []string{"Basic " + credentials}},
+		event:       &event,
+		response:    &created,
+	}, BuildJiraIssue(cfg, event))
+	if err != nil {
+		return "", err
+	}
+	if created.Key == "" {
+		return "", errors.New("jira response did not include an issue key")
+	}
+	return created.Key, nil
+}
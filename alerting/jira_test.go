@@ -0,0 +1,95 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendJiraCreatesIssue(t *testing.T) {
+	var got JiraIssue
+	var user, pass string
+	var authOK bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		user, pass, authOK = r.BasicAuth()
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode issue: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"10001","key":"SEC-42","self":"https://acme.atlassian.net/rest/api/2/issue/10001"}`))
+	}))
+	defer srv.Close()
+
+	cfg := JiraConfig{BaseURL: srv.URL, Email: "bot@acme.com", APIToken: "jira-token", ProjectKey: "SEC"}
+	event := testEvent()
+	event.Severity = SeverityCritical
+	key, err := NewSender(srv.Client(), WithAllowInsecure()).SendJira(context.Background(), cfg, event)
+	if err != nil {
+		t.Fatalf("SendJira returned error: %v", err)
+	}
+
+	if key != "SEC-42" {
+		t.Fatalf("expected issue key SEC-42, got %q", key)
+	}
+	if !authOK || user != "bot@acme.com" || pass != "jira-token" {
+		t.Fatalf("unexpected basic auth %q:%q (ok=%v)", user, pass, authOK)
+	}
+	if got.Fields.Project.Key != "SEC" || got.Fields.IssueType.Name != DefaultJiraIssueType {
+		t.Fatalf("unexpected project/issue type %+v", got.Fields)
+	}
+	if got.Fields.Priority.Name != "Highest" {
+		t.Fatalf("expected Highest priority for critical, got %q", got.Fields.Priority.Name)
+	}
+	if got.Fields.Summary != summaryLine(event) {
+		t.Fatalf("unexpected summary %q", got.Fields.Summary)
+	}
+	if !strings.Contains(got.Fields.Description, "*File:* {{config/settings.py}}") {
+		t.Fatalf("expected file in description, got %q", got.Fields.Description)
+	}
+}
+
+func TestSendJiraSkipsSuppressedEvents(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusCreated}
+	s := NewSender(doer)
+	s.Allowlist = NewAllowlist(AllowRule{Rule: "aws-access-key-id"})
+	cfg := JiraConfig{BaseURL: "https://acme.atlassian.net", Email: "bot@acme.com", APIToken: "jira-token", ProjectKey: "SEC"}
+	key, err := s.SendJira(context.Background(), cfg, testEvent())
+	if err != nil || key != "" {
+		t.Fatalf("expected a suppressed event to return no key and no error, got %q, %v", key, err)
+	}
+	if len(doer.requests) != 0 {
+		t.Fatalf("expected no request for a suppressed event, got %d", len(doer.requests))
+	}
+}
+
+func TestJiraPriority(t *testing.T) {
+	cases := map[Severity]string{
+		SeverityLow:      "Low",
+		SeverityMedium:   "Medium",
+		SeverityUnset:    "Medium",
+		SeverityHigh:     "High",
+		SeverityCritical: "Highest",
+	}
+	for severity, want := range cases {
+		if got := JiraPriority(severity); got != want {
+			t.Errorf("JiraPriority(%q) = %q, want %q", severity, got, want)
+		}
+	}
+}
+
+func TestSendJiraValidatesConfig(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusCreated}
+	_, err := NewSender(doer).SendJira(context.Background(), JiraConfig{BaseURL: "https://acme.atlassian.net"}, testEvent())
+	if err == nil {
+		t.Fatal("expected config validation error")
+	}
+	if len(doer.requests) != 0 {
+		t.Fatal("expected no request for invalid config")
+	}
+}
@@ -0,0 +1,117 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// DefaultJournalSocket is the systemd journal native protocol socket.
+const DefaultJournalSocket = "/run/systemd/journal/socket"
+
+// JournalPriority maps a severity onto a syslog priority: critical is
+// "crit" (2), high "err" (3), medium "warning" (4), and low "notice" (5).
+func JournalPriority(severity Severity) int {
+	switch severity.OrDefault() {
+	case SeverityCritical:
+		return 2
+	case SeverityHigh:
+		return 3
+	case SeverityLow:
+		return 5
+	default:
+		return 4
+	}
+}
+
+// JournalNotifier writes findings to the systemd journal with structured
+// TRIPWIRE_* fields, falling back to Stderr when the socket is absent.
+type JournalNotifier struct {
+	SocketPath string
+	Stderr     io.Writer
+}
+
+func NewJournalNotifier() *JournalNotifier {
+	return &JournalNotifier{SocketPath: DefaultJournalSocket}
+}
+
+func (n *JournalNotifier) Notify(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	fields := journalFields(event)
+
+	socketPath := n.SocketPath
+	if socketPath == "" {
+		socketPath = DefaultJournalSocket
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unixgram", socketPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED) {
+			return n.fallback(event)
+		}
+		return fmt.Errorf("dial journal socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(encodeJournalFields(fields)); err != nil {
+		return fmt.Errorf("write journal entry: %w", err)
+	}
+	return nil
+}
+
+func (n *JournalNotifier) fallback(event Event) error {
+	out := n.Stderr
+	if out == nil {
+		out = os.Stderr
+	}
+	if _, err := fmt.Fprintln(out, summaryLine(event)); err != nil {
+		return fmt.Errorf("write stderr fallback: %w", err)
+	}
+	return nil
+}
+
+type journalField struct {
+	name  string
+	value string
+}
+
+func journalFields(event Event) []journalField {
+	return []journalField{
+		{name: "MESSAGE", value: summaryLine(event)},
+		{name: "PRIORITY", value: fmt.Sprint(JournalPriority(event.Severity))},
+		{name: "SYSLOG_IDENTIFIER", value: "tripwire"},
+		{name: "TRIPWIRE_REPO", value: event.Repository},
+		{name: "TRIPWIRE_BRANCH", value: event.Branch},
+		{name: "TRIPWIRE_COMMIT", value: event.CommitSHA},
+		{name: "TRIPWIRE_RULE", value: event.Rule},
+		{name: "TRIPWIRE_FILE", value: event.FilePath},
+	}
+}
+
+// encodeJournalFields serializes fields in the journal native protocol.
+// Values containing a newline use the length-prefixed binary form.
+func encodeJournalFields(fields []journalField) []byte {
+	var buf bytes.Buffer
+	for _, f := range fields {
+		if !strings.Contains(f.value, "\n") {
+			fmt.Fprintf(&buf, "%s=%s\n", f.name, f.value)
+			continue
+		}
+		buf.WriteString(f.name)
+		buf.WriteByte('\n')
+		_ = binary.Write(&buf, binary.LittleEndian, uint64(len(f.value)))
+		buf.WriteString(f.value)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,87 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournalNotifierWritesFields(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("listen unixgram: %v", err)
+	}
+	defer conn.Close()
+
+	n := &JournalNotifier{SocketPath: socketPath}
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("set deadline: %v", err)
+	}
+	size, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("read datagram: %v", err)
+	}
+	got := string(buf[:size])
+	for _, want := range []string{
+		"MESSAGE=Secret detected in acme/tripwire on main (abc1234)\n",
+		"PRIORITY=4\n",
+		"TRIPWIRE_REPO=acme/tripwire\n",
+		"TRIPWIRE_RULE=aws-access-key-id\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in journal entry %q", want, got)
+		}
+	}
+}
+
+func TestEncodeJournalFieldsMultiline(t *testing.T) {
+	got := encodeJournalFields([]journalField{{name: "MESSAGE", value: "a\nb"}})
+
+	var want bytes.Buffer
+	want.WriteString("MESSAGE\n")
+	_ = binary.Write(&want, binary.LittleEndian, uint64(3))
+	want.WriteString("a\nb\n")
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Fatalf("unexpected binary encoding %q", got)
+	}
+}
+
+func TestJournalNotifierFallsBackToStderr(t *testing.T) {
+	var stderr bytes.Buffer
+	n := &JournalNotifier{
+		SocketPath: filepath.Join(t.TempDir(), "missing.sock"),
+		Stderr:     &stderr,
+	}
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "acme/tripwire") {
+		t.Fatalf("expected summary on stderr, got %q", stderr.String())
+	}
+}
+
+func TestJournalPriority(t *testing.T) {
+	cases := map[Severity]int{
+		SeverityLow:      5,
+		SeverityMedium:   4,
+		SeverityUnset:    4,
+		SeverityHigh:     3,
+		SeverityCritical: 2,
+	}
+	for severity, want := range cases {
+		if got := JournalPriority(severity); got != want {
+			t.Errorf("JournalPriority(%s) = %d, want %d", severity, got, want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// ErrSignatureMismatch is returned when a Lambda function URL rejects the
+// SigV4 signature, which usually means the wrong region or credentials.
+var ErrSignatureMismatch = errors.New("aws rejected request signature")
+
+// LambdaURLSender posts the webhook payload to a Lambda function URL that
+// uses AuthType AWS_IAM, signing each request with SigV4.
+type LambdaURLSender struct {
+	Sender      *Sender
+	Credentials aws.CredentialsProvider
+	Region      string
+	// Clock stamps signatures; nil uses RealClock.
+	Clock Clock
+}
+
+func NewLambdaURLSender(sender *Sender, credentials aws.CredentialsProvider, region string) *LambdaURLSender {
+	return &LambdaURLSender{Sender: sender, Credentials: credentials, Region: region}
+}
+
+func (l *LambdaURLSender) Send(ctx context.Context, functionURL string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	if l.Credentials == nil {
+		return errors.New("aws credentials are required")
+	}
+	if strings.TrimSpace(l.Region) == "" {
+		return errors.New("aws region is required")
+	}
+	sender := senderOrDefault(l.Sender)
+	event = sender.prepareEvent(event)
+
+	err := sender.sendPayload(ctx, outboundRequest{
+		destination: "lambda",
+		method:      http.MethodPost,
+		url:         functionURL,
+		contentType: "application/json",
+		event:       &event,
+		authorize:   l.signRequest,
+	}, BuildWebhookPayload(event))
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusForbidden && isSignatureMismatch(statusErr.header, statusErr.body) {
+		return fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+	return err
+}
+
+// signRequest signs one attempt with SigV4, so a retried request carries a
+// fresh timestamp.
+func (l *LambdaURLSender) signRequest(ctx context.Context, req *http.Request, body []byte) error {
+	creds, err := l.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieve aws credentials: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, payloadHash, "lambda", l.Region, clockOrDefault(l.Clock).Now().UTC()); err != nil {
+		return fmt.Errorf("sign request: %w", err)
+	}
+	return nil
+}
+
+func isSignatureMismatch(header http.Header, body []byte) bool {
+	errorType := header.Get("X-Amzn-ErrorType")
+	if strings.Contains(errorType, "SignatureDoesNotMatch") || strings.Contains(errorType, "InvalidSignatureException") {
+		return true
+	}
+	return bytes.Contains(body, []byte("SignatureDoesNotMatch")) ||
+		bytes.Contains(body, []byte("signature we calculated does not match"))
+}
@@ -0,0 +1,103 @@
+package alerting
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func staticCredentials() aws.CredentialsProvider {
+	return aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+	})
+}
+
+func TestLambdaURLSenderSignsRequest(t *testing.T) {
+	authPattern := regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260226/us-east-1/lambda/aws4_request, SignedHeaders=[a-z0-9;-]*content-type[a-z0-9;-]*, Signature=[0-9a-f]{64}$`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); !authPattern.MatchString(auth) {
+			t.Errorf("unexpected Authorization header %q", auth)
+		}
+		if r.Header.Get("X-Amz-Date") != "20260226T120000Z" {
+			t.Errorf("unexpected X-Amz-Date %q", r.Header.Get("X-Amz-Date"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		if got := r.Header.Get("X-Amz-Content-Sha256"); got != hex.EncodeToString(sum[:]) {
+			t.Errorf("payload hash %q does not match received body", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	l := NewLambdaURLSender(NewSender(srv.Client(), WithAllowInsecure()), staticCredentials(), "us-east-1")
+	l.Clock = ClockFunc(fixedAlertTime)
+	if err := l.Send(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+}
+
+func TestLambdaURLSenderSignatureMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amzn-ErrorType", "InvalidSignatureException")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"The request signature we calculated does not match the signature you provided."}`))
+	}))
+	defer srv.Close()
+
+	l := NewLambdaURLSender(NewSender(srv.Client(), WithAllowInsecure()), staticCredentials(), "us-east-1")
+	err := l.Send(context.Background(), srv.URL, testEvent())
+	if !errors.Is(err, ErrSignatureMismatch) {
+		t.Fatalf("expected signature mismatch error, got %v", err)
+	}
+}
+
+func TestLambdaURLSenderRejectsInvalidFunctionURL(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	l := NewLambdaURLSender(NewSender(doer), staticCredentials(), "us-east-1")
+	for _, functionURL := range []string{"http://abc.lambda-url.us-east-1.on.aws/", "file:///etc/passwd", "abc.lambda-url.us-east-1.on.aws"} {
+		if err := l.Send(context.Background(), functionURL, testEvent()); err == nil {
+			t.Errorf("expected %q to be rejected", functionURL)
+		}
+	}
+	if len(doer.requests) != 0 {
+		t.Fatalf("expected no requests, got %d", len(doer.requests))
+	}
+}
+
+func TestLambdaURLSenderSignsEachRetry(t *testing.T) {
+	var dates []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dates = append(dates, r.Header.Get("X-Amz-Date"))
+		if len(dates) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(srv.Client(), WithAllowInsecure())
+	sender.Retry = fastRetry(2)
+	l := NewLambdaURLSender(sender, staticCredentials(), "us-east-1")
+	clock := fixedAlertTime()
+	l.Clock = ClockFunc(func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	})
+	if err := l.Send(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if len(dates) != 2 || dates[0] == dates[1] {
+		t.Fatalf("expected two requests signed at different times, got %v", dates)
+	}
+}
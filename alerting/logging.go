@@ -0,0 +1,78 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// logAttempt records one delivery attempt. A nil err logs a success.
+func (s *Sender) logAttempt(ctx context.Context, level slog.Level, out outboundRequest, attempt int, err error) {
+	logger := s.Logger
+	if logger == nil || !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("destination", out.destination),
+		slog.Int("attempt", attempt),
+	}
+	host, path := redactedTarget(out)
+	attrs = append(attrs, slog.String("host", host), slog.String("path", path))
+	if out.event != nil {
+		attrs = append(attrs,
+			slog.String("repository", out.event.Repository),
+			slog.String("rule", out.event.Rule),
+		)
+	}
+	if status := responseStatus(err); status != 0 {
+		attrs = append(attrs, slog.Int("status", status))
+	}
+
+	msg := "alert delivered"
+	if err != nil {
+		msg = "alert delivery failed"
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// redactedTarget returns the host and path of the request URL with any
+// credential carried in the URL removed. Query strings are dropped.
+func redactedTarget(out outboundRequest) (string, string) {
+	u, err := url.Parse(out.url)
+	if err != nil {
+		return "", ""
+	}
+	return u.Host, redactPath(u.Path, out.secretInURL)
+}
+
+// redactURL reduces raw to its scheme, host, and path, with secret removed
+// from the path, so transport errors can be logged and traced safely.
+// Unparseable URLs are replaced entirely.
+func redactURL(raw, secret string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "<redacted>"
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: redactPath(u.Path, secret)}).String()
+}
+
+// redactURLError applies redactURL to the URL of the *url.Error in err's
+// chain, if any, and returns err.
+func redactURLError(err error, secret string) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL, secret)
+	}
+	return err
+}
+
+func redactPath(path, secret string) string {
+	if secret == "" {
+		return path
+	}
+	return strings.ReplaceAll(path, secret, "<redacted>")
+}
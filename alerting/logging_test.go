@@ -0,0 +1,89 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSenderLogsFailedDelivery(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewSender(&fakeDoer{status: http.StatusInternalServerError})
+	s.Logger = slogJSONLogger(&buf)
+
+	err := s.SendSlack(context.Background(), "https://hooks.example.com/services/abc?token=s3cr3t", testEvent())
+	if err == nil {
+		t.Fatal("expected error for 500")
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("decode log record %q: %v", buf.String(), err)
+	}
+	want := map[string]any{
+		"level":       "ERROR",
+		"destination": "slack",
+		"host":        "hooks.example.com",
+		"path":        "/services/abc",
+		"repository":  "acme/tripwire",
+		"rule":        "aws-access-key-id",
+		"status":      float64(500),
+		"attempt":     float64(1),
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v", key, record[key], value)
+		}
+	}
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Fatalf("query string leaked into logs: %s", buf.String())
+	}
+}
+
+// transportErrDoer fails every request the way http.Client does, with a
+// *url.Error carrying the full request URL.
+type transportErrDoer struct{}
+
+func (transportErrDoer) Do(req *http.Request) (*http.Response, error) {
+	return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: errors.New("connection refused")}
+}
+
+func TestSenderLogRedactsTransportErrorURL(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewSender(transportErrDoer{})
+	s.Retry = fastRetry(2)
+	s.Logger = slogJSONLogger(&buf)
+
+	err := s.SendWebhook(context.Background(), "https://hooks.example.com/tripwire?token=s3cr3t", testEvent())
+	if err == nil {
+		t.Fatal("expected a transport error")
+	}
+	if strings.Contains(buf.String(), "s3cr3t") || strings.Contains(err.Error(), "s3cr3t") {
+		t.Fatalf("query token leaked: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "https://hooks.example.com/tripwire") {
+		t.Fatalf("expected the redacted URL in the logged error, got %s", buf.String())
+	}
+}
+
+func TestSenderLogRedactsTelegramToken(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewSender(&fakeDoer{status: http.StatusOK})
+	s.Logger = slogJSONLogger(&buf)
+	if err := s.SendTelegram(context.Background(), "123:bot-secret", "42", testEvent()); err != nil {
+		t.Fatalf("SendTelegram returned error: %v", err)
+	}
+	if strings.Contains(buf.String(), "bot-secret") {
+		t.Fatalf("bot token leaked into logs: %s", buf.String())
+	}
+}
+
+func slogJSONLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, nil))
+}
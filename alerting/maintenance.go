@@ -0,0 +1,72 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// MaintenanceWindow silences findings during planned work, such as a
+// migration that commits test credentials. Empty Repository and Rule match
+// anything. The window covers [Start, End).
+type MaintenanceWindow struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Repository string    `json:"repository,omitempty"`
+	Rule       string    `json:"rule,omitempty"`
+}
+
+// Covers reports whether event falls in the window's scope at time at.
+func (w MaintenanceWindow) Covers(event Event, at time.Time) bool {
+	if at.Before(w.Start) || !at.Before(w.End) {
+		return false
+	}
+	if w.Repository != "" && w.Repository != event.Repository {
+		return false
+	}
+	return w.Rule == "" || w.Rule == event.Rule
+}
+
+// Maintenance wraps a Notifier, dropping events covered by any of its
+// windows at send time. Overlapping windows simply both apply.
+type Maintenance struct {
+	Windows  []MaintenanceWindow
+	Notifier Notifier
+	// Sink, when set, receives suppressed events instead, typically a
+	// FileSink kept for review after the window.
+	Sink Notifier
+	// Clock decides which windows are active; nil uses RealClock.
+	Clock Clock
+}
+
+func NewMaintenance(notifier Notifier, windows ...MaintenanceWindow) *Maintenance {
+	return &Maintenance{Windows: windows, Notifier: notifier}
+}
+
+// Active reports whether some window covers event now.
+func (m *Maintenance) Active(event Event) bool {
+	now := m.now()
+	for _, w := range m.Windows {
+		if w.Covers(event, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Maintenance) Notify(ctx context.Context, event Event) error {
+	if m.Notifier == nil {
+		return errors.New("maintenance notifier is required")
+	}
+	if !m.Active(event) {
+		return m.Notifier.Notify(ctx, event)
+	}
+	if m.Sink != nil {
+		return m.Sink.Notify(ctx, event)
+	}
+	return nil
+}
+
+func (m *Maintenance) now() time.Time {
+	return clockOrDefault(m.Clock).Now()
+}
@@ -0,0 +1,64 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceSuppressesInsideWindow(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	pager := &countingNotifier{}
+	m := NewMaintenance(pager,
+		MaintenanceWindow{Start: start, End: start.Add(time.Hour), Repository: "acme/tripwire"},
+		MaintenanceWindow{Start: start.Add(30 * time.Minute), End: start.Add(2 * time.Hour)},
+	)
+	m.Clock = clock
+
+	for _, at := range []time.Duration{-time.Minute, 0, 45 * time.Minute, 90 * time.Minute, 2 * time.Hour} {
+		clock.Advance(start.Add(at).Sub(clock.Now()))
+		if err := m.Notify(context.Background(), testEvent()); err != nil {
+			t.Fatalf("at %v: Notify returned error: %v", at, err)
+		}
+	}
+	if got := pager.calls.Load(); got != 2 {
+		t.Fatalf("expected only the sends before and after the windows, got %d", got)
+	}
+}
+
+func TestMaintenanceWindowScope(t *testing.T) {
+	start := fixedAlertTime()
+	w := MaintenanceWindow{Start: start, End: start.Add(time.Hour), Repository: "acme/tripwire", Rule: "aws-access-key-id"}
+	event := testEvent()
+	if !w.Covers(event, start) {
+		t.Fatal("expected the window to cover a matching event at its start")
+	}
+	if w.Covers(event, start.Add(time.Hour)) {
+		t.Fatal("expected the window end to be exclusive")
+	}
+	event.Rule = "github-token"
+	if w.Covers(event, start) {
+		t.Fatal("expected a different rule to fall outside the scope")
+	}
+}
+
+func TestMaintenanceWritesSuppressedToSink(t *testing.T) {
+	start := fixedAlertTime()
+	pager := &countingNotifier{}
+	var buf bytes.Buffer
+	m := NewMaintenance(pager, MaintenanceWindow{Start: start, End: start.Add(time.Hour)})
+	m.Sink = NewFileSink(&buf)
+	m.Clock = ClockFunc(func() time.Time { return start })
+
+	if err := m.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if pager.calls.Load() != 0 {
+		t.Fatal("expected no page during the window")
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"rule":"aws-access-key-id"`)) {
+		t.Fatalf("expected the suppressed event in the sink, got %q", buf.String())
+	}
+}
@@ -0,0 +1,57 @@
+package alerting
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+)
+
+const maskChar = "*"
+
+// MaskSpan replaces the bytes in line[start:end] with a mask of the same
+// length. A span that splits a multi-byte rune is widened to cover it, so
+// the result stays valid UTF-8. An out-of-bounds or inverted span masks the
+// whole line rather than risk showing part of a secret.
+func MaskSpan(line string, start, end int) string {
+	if start < 0 || end > len(line) || start > end {
+		return strings.Repeat(maskChar, len(line))
+	}
+	for start > 0 && !utf8.RuneStart(line[start]) {
+		start--
+	}
+	for end < len(line) && !utf8.RuneStart(line[end]) {
+		end++
+	}
+	return line[:start] + strings.Repeat(maskChar, end-start) + line[end:]
+}
+
+// redactMinLen is the shortest secret Redact will partially reveal;
+// anything shorter is masked entirely.
+const redactMinLen = 8
+
+// MaskAuthor returns a copy of e whose Author is replaced by a stable
+// pseudonym, "author-" plus the first 12 hex digits of HMAC-SHA256(salt,
+// Author). The same author and salt always give the same token, so alerts
+// can still be correlated without revealing who committed.
+func (e Event) MaskAuthor(salt []byte) Event {
+	if e.Author == "" {
+		return e
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(e.Author))
+	e.Author = "author-" + hex.EncodeToString(mac.Sum(nil))[:12]
+	return e
+}
+
+// Redact returns a preview of secret showing only its first and last two
+// characters, e.g. "AK****XZ". The mask has a fixed width so the preview
+// does not reveal the secret's length.
+func Redact(secret string) string {
+	runes := []rune(secret)
+	if len(runes) < redactMinLen {
+		return strings.Repeat(maskChar, 4)
+	}
+	return string(runes[:2]) + strings.Repeat(maskChar, 4) + string(runes[len(runes)-2:])
+}
@@ -0,0 +1,74 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MatrixMessage is an m.room.message event with an HTML rendering.
+type MatrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// BuildMatrixMessage renders the finding as an m.text message with a plain
+// body and an org.matrix.custom.html formatted body.
+func BuildMatrixMessage(event Event, opts ...RenderOption) MatrixMessage {
+	summary := summaryLine(event, opts...)
+	plain := []string{"Secret Leak Detected", summary, "", FormatEventDetail(event, DetailPlain, opts...)}
+	var formatted strings.Builder
+	formatted.WriteString("<p><strong>Secret Leak Detected</strong><br>" + html.EscapeString(summary) + "</p><ul>")
+	for _, f := range detailFields(event) {
+		fmt.Fprintf(&formatted, "<li><strong>%s:</strong> <code>%s</code></li>", html.EscapeString(f.name), html.EscapeString(f.value))
+	}
+	formatted.WriteString("</ul>")
+	return MatrixMessage{
+		MsgType:       "m.text",
+		Body:          strings.Join(plain, "\n"),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formatted.String(),
+	}
+}
+
+// MatrixTxnID is the transaction ID SendMatrix uses for event. It is
+// derived from Event.IdempotencyKey, so a retried send is deduplicated by
+// the homeserver instead of posting twice.
+func MatrixTxnID(event Event) string {
+	return "tripwire-" + event.IdempotencyKey()[:32]
+}
+
+// SendMatrix posts the finding to roomID on a Matrix homeserver through the
+// client-server API, authenticating with accessToken.
+func (s *Sender) SendMatrix(ctx context.Context, homeserverURL, accessToken, roomID string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	if strings.TrimSpace(homeserverURL) == "" {
+		return errors.New("matrix homeserver URL is required")
+	}
+	if strings.TrimSpace(accessToken) == "" {
+		return errors.New("matrix access token is required")
+	}
+	if strings.TrimSpace(roomID) == "" {
+		return errors.New("matrix room ID is required")
+	}
+
+	endpoint := strings.TrimRight(homeserverURL, "/") + "/_matrix/client/v3/rooms/" +
+		url.PathEscape(roomID) + "/send/m.room.message/" + MatrixTxnID(event)
+	return s.sendPayload(ctx, outboundRequest{
+		destination: "matrix",
+		method:      http.MethodPut,
+		url:         endpoint,
+		contentType: "application/json",
+		header:      http.Header{"Authorization": {"Bearer " + accessToken}},
+		event:       &event,
+	}, BuildMatrixMessage(event, s.RenderOptions...))
+}
@@ -0,0 +1,62 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendMatrixPutsRoomMessage(t *testing.T) {
+	var gotMethod, gotPath, gotAuth string
+	var got MatrixMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotAuth = r.Method, r.URL.Path, r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.Write([]byte(`{"event_id":"$abc"}`))
+	}))
+	defer srv.Close()
+
+	event := testEvent()
+	err := NewSender(srv.Client(), WithAllowInsecure()).SendMatrix(context.Background(), srv.URL+"/", "syt_token", "!room:example.org", event)
+	if err != nil {
+		t.Fatalf("SendMatrix returned error: %v", err)
+	}
+
+	if gotMethod != http.MethodPut || gotAuth != "Bearer syt_token" {
+		t.Fatalf("unexpected method %q or Authorization %q", gotMethod, gotAuth)
+	}
+	wantPath := "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/" + MatrixTxnID(event)
+	if gotPath != wantPath {
+		t.Fatalf("path = %q, want %q", gotPath, wantPath)
+	}
+	if got.MsgType != "m.text" || !strings.Contains(got.Body, "config/settings.py") {
+		t.Fatalf("unexpected message %+v", got)
+	}
+	if !strings.Contains(got.FormattedBody, "<code>aws-access-key-id</code>") {
+		t.Fatalf("expected an HTML detail list, got %q", got.FormattedBody)
+	}
+}
+
+func TestMatrixTxnIDIsDeterministic(t *testing.T) {
+	other := testEvent()
+	other.LineNumber = 7
+	if MatrixTxnID(testEvent()) != MatrixTxnID(testEvent()) {
+		t.Fatal("expected the same event to reuse its transaction ID")
+	}
+	if MatrixTxnID(testEvent()) == MatrixTxnID(other) {
+		t.Fatal("expected a different finding to get a new transaction ID")
+	}
+}
+
+func TestBuildMatrixMessageEscapesHTML(t *testing.T) {
+	event := testEvent()
+	event.FilePath = "src/<script>.js"
+	if got := BuildMatrixMessage(event).FormattedBody; strings.Contains(got, "<script>") {
+		t.Fatalf("expected the file path to be escaped, got %q", got)
+	}
+}
@@ -0,0 +1,53 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Metrics receives delivery telemetry from a Sender, e.g. to back
+// Prometheus counters. Implementations must be safe for concurrent use.
+// Destination names are short constants such as "slack" or "webhook".
+type Metrics interface {
+	// IncSent counts a delivery that succeeded.
+	IncSent(destination string)
+	// IncFailed counts a delivery that was given up on. reason is low
+	// cardinality: "status_<code>", "timeout", "canceled", or "error".
+	IncFailed(destination, reason string)
+	// IncRetried counts an attempt that failed and will be retried.
+	IncRetried(destination string)
+	// ObserveLatency records the duration of one attempt.
+	ObserveLatency(destination string, d time.Duration)
+}
+
+// NopMetrics discards all telemetry.
+type NopMetrics struct{}
+
+func (NopMetrics) IncSent(string)                       {}
+func (NopMetrics) IncFailed(string, string)             {}
+func (NopMetrics) IncRetried(string)                    {}
+func (NopMetrics) ObserveLatency(string, time.Duration) {}
+
+func (s *Sender) metrics() Metrics {
+	if s.Metrics != nil {
+		return s.Metrics
+	}
+	return NopMetrics{}
+}
+
+// failureReason buckets a send error for IncFailed.
+func failureReason(err error) string {
+	if status := responseStatus(err); status != 0 {
+		return "status_" + strconv.Itoa(status)
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,91 @@
+package alerting
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeMetrics struct {
+	mu        sync.Mutex
+	sent      map[string]int
+	failed    map[string]int
+	retried   map[string]int
+	latencies int
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{sent: map[string]int{}, failed: map[string]int{}, retried: map[string]int{}}
+}
+
+func (m *fakeMetrics) IncSent(destination string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent[destination]++
+}
+
+func (m *fakeMetrics) IncFailed(destination, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed[destination+"/"+reason]++
+}
+
+func (m *fakeMetrics) IncRetried(destination string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retried[destination]++
+}
+
+func (m *fakeMetrics) ObserveLatency(string, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latencies++
+}
+
+func TestSenderRecordsMetrics(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	metrics := newFakeMetrics()
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.Metrics = metrics
+
+	if err := s.SendSlack(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendSlack returned error: %v", err)
+	}
+	status = http.StatusInternalServerError
+	if err := s.SendSlack(context.Background(), srv.URL, testEvent()); err == nil {
+		t.Fatal("expected error for 500")
+	}
+
+	if metrics.sent["slack"] != 1 {
+		t.Fatalf("expected 1 success, got %v", metrics.sent)
+	}
+	if metrics.failed["slack/status_500"] != 1 {
+		t.Fatalf("expected 1 status_500 failure, got %v", metrics.failed)
+	}
+	if metrics.latencies != 2 {
+		t.Fatalf("expected 2 latency observations, got %d", metrics.latencies)
+	}
+}
+
+func TestSenderRecordsRetries(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusBadGateway}
+	metrics := newFakeMetrics()
+	s := NewSender(doer)
+	s.Metrics = metrics
+	s.Retry = fastRetry(3)
+
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err == nil {
+		t.Fatal("expected error")
+	}
+	if metrics.retried["webhook"] != 2 || metrics.failed["webhook/status_502"] != 1 {
+		t.Fatalf("unexpected metrics retried=%v failed=%v", metrics.retried, metrics.failed)
+	}
+}
@@ -0,0 +1,96 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// DefaultMQTTTopicPrefix is the topic prefix findings are published under.
+const DefaultMQTTTopicPrefix = "tripwire/findings"
+
+// Publisher adapters wrap these errors so MQTTSender can tell transient
+// broker failures from permanent ones such as a rejected login or topic.
+var (
+	ErrMQTTConnectionLost = errors.New("mqtt connection lost")
+	ErrMQTTPublishTimeout = errors.New("mqtt publish timed out")
+)
+
+// mqttPublisher is the part of an MQTT client used by MQTTSender. Callers
+// adapt their broker connection to it.
+type mqttPublisher interface {
+	Publish(ctx context.Context, topic string, qos byte, payload []byte) error
+}
+
+// MQTTSender publishes the webhook JSON payload for each finding to
+// <TopicPrefix>/<repository>.
+type MQTTSender struct {
+	publisher   mqttPublisher
+	TopicPrefix string
+	QoS         byte
+}
+
+func NewMQTTSender(publisher mqttPublisher, qos byte) *MQTTSender {
+	return &MQTTSender{publisher: publisher, TopicPrefix: DefaultMQTTTopicPrefix, QoS: qos}
+}
+
+func (m *MQTTSender) Send(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	if m.publisher == nil {
+		return errors.New("mqtt publisher is required")
+	}
+	if m.QoS > 2 {
+		return fmt.Errorf("invalid mqtt qos %d", m.QoS)
+	}
+
+	body, err := json.Marshal(BuildWebhookPayload(event))
+	if err != nil {
+		return fmt.Errorf("marshal mqtt payload: %w", err)
+	}
+	topic := MQTTTopic(m.TopicPrefix, event.Repository)
+	if err := m.publisher.Publish(ctx, topic, m.QoS, body); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return canceledError("mqtt", ctxErr)
+		}
+		return newSendError("mqtt", mqttRetryable(err), fmt.Errorf("publish to %s: %w", topic, err))
+	}
+	return nil
+}
+
+// mqttRetryable reports whether a publish failure is transient: a lost
+// connection, a publish token that timed out, or a network error. Anything
+// else, such as an authorization or topic rejection, is permanent.
+func mqttRetryable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, ErrMQTTConnectionLost) || errors.Is(err, ErrMQTTPublishTimeout) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, io.EOF) || errors.As(err, &netErr)
+}
+
+// MQTTTopic joins prefix and repository into a publishable topic. The
+// wildcard characters + and # and NUL are not allowed in published topics
+// and are replaced, and empty levels are dropped.
+func MQTTTopic(prefix, repository string) string {
+	if strings.TrimSpace(prefix) == "" {
+		prefix = DefaultMQTTTopicPrefix
+	}
+	var levels []string
+	for _, level := range strings.Split(prefix+"/"+repository, "/") {
+		level = strings.Map(func(r rune) rune {
+			if r == '+' || r == '#' || r == 0 {
+				return '_'
+			}
+			return r
+		}, strings.TrimSpace(level))
+		if level != "" {
+			levels = append(levels, level)
+		}
+	}
+	return strings.Join(levels, "/")
+}
@@ -0,0 +1,82 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type fakeMQTTPublisher struct {
+	topic   string
+	qos     byte
+	payload []byte
+	err     error
+}
+
+func (p *fakeMQTTPublisher) Publish(_ context.Context, topic string, qos byte, payload []byte) error {
+	p.topic, p.qos, p.payload = topic, qos, payload
+	return p.err
+}
+
+func TestMQTTSenderPublishesWebhookJSON(t *testing.T) {
+	publisher := &fakeMQTTPublisher{}
+	m := NewMQTTSender(publisher, 1)
+	if err := m.Send(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if publisher.topic != "tripwire/findings/acme/tripwire" || publisher.qos != 1 {
+		t.Fatalf("unexpected topic %q qos %d", publisher.topic, publisher.qos)
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(publisher.payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.Event != "secret.detected" || payload.Repository != "acme/tripwire" || payload.Rule != "aws-access-key-id" {
+		t.Fatalf("expected webhook payload, got %+v", payload)
+	}
+}
+
+func TestMQTTSenderClassifiesPublishFailures(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"connection lost", fmt.Errorf("paho: %w", ErrMQTTConnectionLost), true},
+		{"publish timeout", fmt.Errorf("token wait: %w", ErrMQTTPublishTimeout), true},
+		{"deadline", context.DeadlineExceeded, true},
+		{"not authorized", errors.New("not authorized"), false},
+		{"bad topic", errors.New("topic name invalid"), false},
+	}
+	for _, tc := range cases {
+		m := NewMQTTSender(&fakeMQTTPublisher{err: tc.err}, 1)
+		err := m.Send(context.Background(), testEvent())
+		var sendErr *SendError
+		if !errors.As(err, &sendErr) {
+			t.Fatalf("%s: expected a SendError, got %v", tc.name, err)
+		}
+		if sendErr.Retryable != tc.retryable || sendErr.Destination != "mqtt" {
+			t.Errorf("%s: got %+v, want retryable %v", tc.name, sendErr, tc.retryable)
+		}
+	}
+}
+
+func TestMQTTSenderReportsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m := NewMQTTSender(&fakeMQTTPublisher{err: context.Canceled}, 1)
+	err := m.Send(ctx, testEvent())
+	var sendErr *SendError
+	if !errors.Is(err, context.Canceled) || errors.As(err, &sendErr) {
+		t.Fatalf("expected a cancellation error, got %v", err)
+	}
+}
+
+func TestMQTTTopicSanitizes(t *testing.T) {
+	if got := MQTTTopic("", "acme/+weird#//repo"); got != "tripwire/findings/acme/_weird_/repo" {
+		t.Fatalf("unexpected topic %q", got)
+	}
+}
@@ -0,0 +1,36 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MultiSender fans one event out to several destinations concurrently.
+type MultiSender struct {
+	Destinations []Destination
+}
+
+func NewMultiSender(destinations ...Destination) *MultiSender {
+	return &MultiSender{Destinations: destinations}
+}
+
+// Send delivers event to every destination and waits for all of them. A
+// failing destination does not stop the others; their errors are joined.
+func (m *MultiSender) Send(ctx context.Context, event Event) error {
+	errs := make([]error, len(m.Destinations))
+	var wg sync.WaitGroup
+	for i, destination := range m.Destinations {
+		if destination == nil {
+			continue
+		}
+		wg.Go(func() {
+			if err := destination(ctx, event); err != nil {
+				errs[i] = fmt.Errorf("destination %d: %w", i, err)
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
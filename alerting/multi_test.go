@@ -0,0 +1,44 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestMultiSenderAttemptsEveryDestination(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]Event{}
+	record := func(name string) Destination {
+		return func(ctx context.Context, event Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received[name] = event
+			return nil
+		}
+	}
+	pagerErr := errors.New("pagerduty unavailable")
+
+	m := NewMultiSender(
+		record("slack"),
+		func(context.Context, Event) error { return pagerErr },
+		record("webhook"),
+	)
+	err := m.Send(context.Background(), testEvent())
+	if !errors.Is(err, pagerErr) {
+		t.Fatalf("expected joined pagerduty error, got %v", err)
+	}
+	for _, name := range []string{"slack", "webhook"} {
+		if received[name].Repository != testEvent().Repository {
+			t.Fatalf("expected %s to receive the event", name)
+		}
+	}
+}
+
+func TestMultiSenderNoErrors(t *testing.T) {
+	ok := func(context.Context, Event) error { return nil }
+	if err := NewMultiSender(ok, ok).Send(context.Background(), testEvent()); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
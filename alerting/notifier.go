@@ -0,0 +1,87 @@
+package alerting
+
+import "context"
+
+// Notifier delivers a finding to one configured destination.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// Notify makes a Destination a Notifier, which also adapts method values
+// such as (*AlertmanagerSender).Send.
+func (d Destination) Notify(ctx context.Context, event Event) error {
+	return d(ctx, event)
+}
+
+// SlackNotifier posts to a Slack incoming webhook.
+type SlackNotifier struct {
+	Sender     *Sender
+	WebhookURL string
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, event Event) error {
+	return senderOrDefault(n.Sender).SendSlack(ctx, n.WebhookURL, event)
+}
+
+// DiscordNotifier posts to a Discord webhook.
+type DiscordNotifier struct {
+	Sender     *Sender
+	WebhookURL string
+}
+
+func (n *DiscordNotifier) Notify(ctx context.Context, event Event) error {
+	return senderOrDefault(n.Sender).SendDiscord(ctx, n.WebhookURL, event)
+}
+
+// TeamsNotifier posts to a Microsoft Teams workflow webhook.
+type TeamsNotifier struct {
+	Sender     *Sender
+	WebhookURL string
+}
+
+func (n *TeamsNotifier) Notify(ctx context.Context, event Event) error {
+	return senderOrDefault(n.Sender).SendTeams(ctx, n.WebhookURL, event)
+}
+
+// WebhookNotifier posts the generic JSON payload.
+type WebhookNotifier struct {
+	Sender     *Sender
+	WebhookURL string
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	return senderOrDefault(n.Sender).SendWebhook(ctx, n.WebhookURL, event)
+}
+
+// PagerDutyNotifier triggers a PagerDuty Events API v2 incident.
+type PagerDutyNotifier struct {
+	Sender     *Sender
+	RoutingKey string
+}
+
+func (n *PagerDutyNotifier) Notify(ctx context.Context, event Event) error {
+	return senderOrDefault(n.Sender).SendPagerDuty(ctx, n.RoutingKey, event)
+}
+
+func senderOrDefault(s *Sender) *Sender {
+	if s == nil {
+		return NewSender(nil)
+	}
+	return s
+}
+
+var (
+	_ Notifier = Destination(nil)
+	_ Notifier = (*SlackNotifier)(nil)
+	_ Notifier = (*DiscordNotifier)(nil)
+	_ Notifier = (*TeamsNotifier)(nil)
+	_ Notifier = (*WebhookNotifier)(nil)
+	_ Notifier = (*PagerDutyNotifier)(nil)
+	_ Notifier = (*StatsDNotifier)(nil)
+	_ Notifier = (*JournalNotifier)(nil)
+	_ Notifier = (*DesktopNotifier)(nil)
+	_ Notifier = (*S3Notifier)(nil)
+	_ Notifier = (*Router)(nil)
+	_ Notifier = (*FileSink)(nil)
+	_ Notifier = (*Maintenance)(nil)
+)
@@ -0,0 +1,47 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotifierListFiresEveryDestination(t *testing.T) {
+	hits := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		hits[r.URL.Path]++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(srv.Client(), WithAllowInsecure())
+	notifiers := []Notifier{
+		&SlackNotifier{Sender: sender, WebhookURL: srv.URL + "/slack"},
+		&WebhookNotifier{Sender: sender, WebhookURL: srv.URL + "/webhook"},
+	}
+	for _, n := range notifiers {
+		if err := n.Notify(context.Background(), testEvent()); err != nil {
+			t.Fatalf("%T returned error: %v", n, err)
+		}
+	}
+	if hits["/slack"] != 1 || hits["/webhook"] != 1 {
+		t.Fatalf("expected one hit per notifier, got %v", hits)
+	}
+}
+
+func TestDestinationIsNotifier(t *testing.T) {
+	called := false
+	var n Notifier = Destination(func(context.Context, Event) error {
+		called = true
+		return nil
+	})
+	if err := n.Notify(context.Background(), testEvent()); err != nil || !called {
+		t.Fatalf("expected destination to be called, err=%v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package alerting
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultSendTimeout bounds each outbound request when WithTimeout is not
+// given.
+const DefaultSendTimeout = 10 * time.Second
+
+// Version is the tripwire release in the default User-Agent. Release
+// builds set it with -ldflags "-X main/alerting.Version=1.2.3".
+var Version = "dev"
+
+// DefaultUserAgent returns the User-Agent sent when WithUserAgent is not
+// given, e.g. "tripwire/1.2.3".
+func DefaultUserAgent() string {
+	return "tripwire/" + Version
+}
+
+// SenderOption configures a Sender built by NewSender.
+type SenderOption func(*Sender)
+
+// WithRateLimit paces outbound requests with a token bucket refilled at rps
+// tokens per second and holding up to burst tokens. Sends block until a
+// token is available or their context is done.
+func WithRateLimit(rps float64, burst int) SenderOption {
+	return func(s *Sender) {
+		s.limiter = rate.NewLimiter(rate.Limit(rps), max(burst, 1))
+	}
+}
+
+// WithTimeout bounds each outbound request, including reading its
+// response, to d. A caller's earlier context deadline still wins.
+// Non-positive values use DefaultSendTimeout.
+func WithTimeout(d time.Duration) SenderOption {
+	return func(s *Sender) {
+		s.timeout = d
+	}
+}
+
+// WithTransport sends through an *http.Client using rt, e.g. an
+// *http.Transport with a corporate proxy or client certificates for mTLS.
+// It replaces the doer passed to NewSender. Without it the default client
+// already honours HTTP_PROXY, HTTPS_PROXY, and NO_PROXY.
+func WithTransport(rt http.RoundTripper) SenderOption {
+	return func(s *Sender) {
+		if rt != nil {
+			s.Doer = &http.Client{Transport: rt}
+		}
+	}
+}
+
+// WithUserAgent replaces DefaultUserAgent on every outbound request, e.g.
+// to match a receiver's WAF allowlist. Empty keeps the default.
+func WithUserAgent(ua string) SenderOption {
+	return func(s *Sender) {
+		s.userAgent = ua
+	}
+}
+
+// WithAllowInsecure sets Sender.AllowInsecure, for sending to a local
+// receiver such as an httptest server.
+func WithAllowInsecure() SenderOption {
+	return func(s *Sender) {
+		s.AllowInsecure = true
+	}
+}
+
+// WithAuthorHashing replaces each event's author with MaskAuthor(salt)
+// before any payload is built, for orgs that may not share developer
+// emails with third-party chat tools.
+func WithAuthorHashing(salt []byte) SenderOption {
+	return func(s *Sender) {
+		s.hashAuthors = true
+		s.authorSalt = bytes.Clone(salt)
+	}
+}
+
+// WithHeaders attaches fixed headers, such as a gateway's X-Auth-Token, to
+// every outbound request. Headers a destination sets itself take precedence,
+// and Content-Type is always chosen by the destination.
+func WithHeaders(headers map[string]string) SenderOption {
+	return func(s *Sender) {
+		if s.headers == nil {
+			s.headers = make(http.Header, len(headers))
+		}
+		for name, value := range headers {
+			if http.CanonicalHeaderKey(name) == "Content-Type" {
+				continue
+			}
+			s.headers.Set(name, value)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimitPacesSends(t *testing.T) {
+	const (
+		sends = 6
+		rps   = 20.0
+		burst = 2
+	)
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer, WithRateLimit(rps, burst))
+
+	start := time.Now()
+	for range sends {
+		if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+			t.Fatalf("SendWebhook returned error: %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	want := time.Duration(float64(sends-burst) / rps * float64(time.Second))
+	if elapsed < want {
+		t.Fatalf("expected sends to take at least %v, took %v", want, elapsed)
+	}
+	if len(doer.requests) != sends {
+		t.Fatalf("expected %d requests, got %d", sends, len(doer.requests))
+	}
+}
+
+func TestWithRateLimitHonorsContext(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer, WithRateLimit(0.001, 1))
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("first send returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := s.SendWebhook(ctx, "https://example.com/hook", testEvent())
+	if err == nil {
+		t.Fatal("expected rate-limited send to fail once the context is done")
+	}
+	if len(doer.requests) != 1 {
+		t.Fatalf("expected the second request to be held back, got %d requests", len(doer.requests))
+	}
+}
+
+func TestWithTimeoutAbortsSlowSend(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	s := NewSender(srv.Client(), WithAllowInsecure(), WithTimeout(50*time.Millisecond))
+	start := time.Now()
+	err := s.SendWebhook(context.Background(), srv.URL, testEvent())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("send took %v despite a 50ms timeout", elapsed)
+	}
+}
+
+func TestWithTimeoutExcludesRateLimitWait(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	// The second send waits 500ms for a token, longer than the timeout.
+	s := NewSender(srv.Client(), WithAllowInsecure(), WithRateLimit(2, 1), WithTimeout(200*time.Millisecond))
+	for i := range 2 {
+		if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+			t.Fatalf("send %d returned error: %v", i+1, err)
+		}
+	}
+}
+
+func TestWithTimeoutKeepsShorterCallerDeadline(t *testing.T) {
+	var deadline time.Time
+	s := NewSender(nil, WithTimeout(time.Hour))
+	s.RequestHook = func(req *http.Request) error {
+		deadline, _ = req.Context().Deadline()
+		return nil
+	}
+	s.Doer = &fakeDoer{status: http.StatusOK}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.SendWebhook(ctx, "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if time.Until(deadline) > time.Second {
+		t.Fatalf("expected the caller's 1s deadline to win, got %v", time.Until(deadline))
+	}
+}
+
+type recordingRoundTripper struct {
+	used int
+}
+
+func (rt *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.used++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       http.NoBody,
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func TestWithTransportIsUsed(t *testing.T) {
+	rt := &recordingRoundTripper{}
+	s := NewSender(nil, WithTransport(rt))
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if rt.used != 1 {
+		t.Fatalf("expected custom transport to be used once, got %d", rt.used)
+	}
+}
+
+func TestWithHeadersAttachesStaticHeaders(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer, WithHeaders(map[string]string{
+		"X-Auth-Token": "gateway-token",
+		"content-type": "text/plain",
+	}))
+	if err := s.SendSlack(context.Background(), "https://hooks.slack.com/services/T/B/X", testEvent()); err != nil {
+		t.Fatalf("SendSlack returned error: %v", err)
+	}
+	if err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+
+	if len(doer.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(doer.requests))
+	}
+	for _, req := range doer.requests {
+		if got := req.Header.Get("X-Auth-Token"); got != "gateway-token" {
+			t.Fatalf("expected X-Auth-Token on %s, got %q", req.URL, got)
+		}
+		if got := req.Header.Get("Content-Type"); got != "application/json" {
+			t.Fatalf("expected Content-Type to stay application/json on %s, got %q", req.URL, got)
+		}
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	if err := NewSender(doer).SendWebhook(context.Background(), "https://example.com/hook", testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if err := NewSender(doer, WithUserAgent("acme-scanner/2.0")).SendSlack(context.Background(), "https://hooks.slack.com/services/T/B/X", testEvent()); err != nil {
+		t.Fatalf("SendSlack returned error: %v", err)
+	}
+
+	if got := doer.requests[0].Header.Get("User-Agent"); got != "tripwire/"+Version {
+		t.Fatalf("expected the default User-Agent, got %q", got)
+	}
+	if got := doer.requests[1].Header.Get("User-Agent"); got != "acme-scanner/2.0" {
+		t.Fatalf("expected the custom User-Agent, got %q", got)
+	}
+}
+
+func TestWithAuthorHashingKeepsEmailOutOfPayloads(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer, WithAuthorHashing([]byte("org-salt")))
+	ctx := context.Background()
+	event := testEvent()
+	sends := []func() error{
+		func() error { return s.SendSlack(ctx, "https://hooks.slack.com/services/T/B/X", event) },
+		func() error { return s.SendDiscord(ctx, "https://discord.com/api/webhooks/1/x", event) },
+		func() error { return s.SendTeams(ctx, "https://example.com/teams", event) },
+		func() error { return s.SendWebhook(ctx, "https://example.com/hook", event) },
+	}
+	for _, send := range sends {
+		if err := send(); err != nil {
+			t.Fatalf("send returned error: %v", err)
+		}
+	}
+
+	want := event.MaskAuthor([]byte("org-salt")).Author
+	for _, req := range doer.requests {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+		if strings.Contains(string(body), event.Author) {
+			t.Fatalf("raw author leaked to %s: %s", req.URL, body)
+		}
+		if !strings.Contains(string(body), want) {
+			t.Fatalf("expected hashed author %q in %s: %s", want, req.URL, body)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pagerDutyEventsURL is the Events API v2 enqueue endpoint; tests point it
+// at a local server.
+var pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+const pagerDutySummaryLimit = 1024
+
+// PagerDutySeverity maps a severity onto the Events API v2 severities.
+// Medium, and so an unset severity, maps to "error".
+func PagerDutySeverity(severity Severity) string {
+	switch severity.OrDefault() {
+	case SeverityCritical:
+		return "critical"
+	case SeverityLow:
+		return "info"
+	default:
+		return "error"
+	}
+}
+
+// PagerDutyPayload is an Events API v2 trigger event.
+type PagerDutyPayload struct {
+	RoutingKey  string               `json:"routing_key"`
+	EventAction string               `json:"event_action"`
+	DedupKey    string               `json:"dedup_key"`
+	Payload     PagerDutyEventDetail `json:"payload"`
+}
+
+// PagerDutyEventDetail is the payload block of a PagerDuty trigger event.
+type PagerDutyEventDetail struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// PagerDutyDedupKey identifies a finding across commits so repeated
+// detections of the same rule in the same file coalesce into one incident.
+func PagerDutyDedupKey(event Event) string {
+	return "tripwire-" + event.Fingerprint()[:32]
+}
+
+func BuildPagerDutyPayload(routingKey string, event Event) PagerDutyPayload {
+	return PagerDutyPayload{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		DedupKey:    PagerDutyDedupKey(event),
+		Payload: PagerDutyEventDetail{
+			Summary:   truncateRunes(summaryLine(event), pagerDutySummaryLimit),
+			Source:    event.Repository,
+			Severity:  PagerDutySeverity(event.Severity),
+			Timestamp: event.DetectedAt.UTC().Format(time.RFC3339),
+			Component: event.FilePath,
+			Group:     event.Repository,
+			Class:     event.Rule,
+			CustomDetails: map[string]string{
+				"repository": event.Repository,
+				"branch":     event.Branch,
+				"commit_sha": event.CommitSHA,
+				"rule":       event.Rule,
+				"file_path":  event.FilePath,
+				"author":     event.Author,
+			},
+		},
+	}
+}
+
+func (s *Sender) SendPagerDuty(ctx context.Context, routingKey string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	if strings.TrimSpace(routingKey) == "" {
+		return errors.New("pagerduty routing key is required")
+	}
+	return s.sendJSON(ctx, "pagerduty", pagerDutyEventsURL, &event, BuildPagerDutyPayload(routingKey, event))
+}
@@ -0,0 +1,78 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func withPagerDutyURL(t *testing.T, url string) {
+	t.Helper()
+	previous := pagerDutyEventsURL
+	pagerDutyEventsURL = url
+	t.Cleanup(func() { pagerDutyEventsURL = previous })
+}
+
+func TestSendPagerDutySendsDedupKey(t *testing.T) {
+	var got []PagerDutyPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload PagerDutyPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		got = append(got, payload)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	withPagerDutyURL(t, srv.URL)
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	first := testEvent()
+	second := testEvent()
+	second.CommitSHA = "fedcba9876543210"
+	for _, event := range []Event{first, second} {
+		if err := s.SendPagerDuty(context.Background(), "routing-key", event); err != nil {
+			t.Fatalf("SendPagerDuty returned error: %v", err)
+		}
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(got))
+	}
+	if got[0].DedupKey == "" || got[0].DedupKey != got[1].DedupKey {
+		t.Fatalf("expected a shared dedup_key across commits, got %q and %q", got[0].DedupKey, got[1].DedupKey)
+	}
+	if got[0].RoutingKey != "routing-key" || got[0].EventAction != "trigger" {
+		t.Fatalf("unexpected envelope %+v", got[0])
+	}
+	if got[0].Payload.Severity != "error" || got[0].Payload.Source != "acme/tripwire" {
+		t.Fatalf("unexpected payload %+v", got[0].Payload)
+	}
+}
+
+func TestPagerDutyDedupKeyDiffersByRule(t *testing.T) {
+	other := testEvent()
+	other.Rule = "github-token"
+	if PagerDutyDedupKey(testEvent()) == PagerDutyDedupKey(other) {
+		t.Fatal("expected different rules to produce different dedup keys")
+	}
+}
+
+func TestPagerDutySeverity(t *testing.T) {
+	cases := map[Severity]string{
+		SeverityLow:      "info",
+		SeverityMedium:   "error",
+		SeverityUnset:    "error",
+		SeverityHigh:     "error",
+		SeverityCritical: "critical",
+	}
+	for severity, want := range cases {
+		event := testEvent()
+		event.Severity = severity
+		if got := BuildPagerDutyPayload("routing-key", event).Payload.Severity; got != want {
+			t.Errorf("severity %s: payload severity %q, want %q", severity, got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package alerting
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// ErrCertificatePinMismatch is returned when a server's leaf certificate
+// does not match any configured SPKI pin.
+var ErrCertificatePinMismatch = errors.New("TLS certificate does not match any pinned SPKI SHA-256")
+
+// SPKISHA256 returns the SHA-256 of a certificate's SubjectPublicKeyInfo,
+// the value PinTLSConfig compares against.
+func SPKISHA256(cert *x509.Certificate) []byte {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return sum[:]
+}
+
+// PinTLSConfig returns a copy of cfg (or a fresh config when nil) that
+// rejects the handshake unless the leaf certificate's SPKI SHA-256 matches
+// one of pins. Normal chain verification still applies.
+func PinTLSConfig(cfg *tls.Config, pins [][]byte) *tls.Config {
+	pinned := &tls.Config{}
+	if cfg != nil {
+		pinned = cfg.Clone()
+	}
+	pinned.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return ErrCertificatePinMismatch
+		}
+		leaf, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("parse leaf certificate: %w", err)
+		}
+		got := SPKISHA256(leaf)
+		for _, pin := range pins {
+			if bytes.Equal(got, pin) {
+				return nil
+			}
+		}
+		return ErrCertificatePinMismatch
+	}
+	return pinned
+}
@@ -0,0 +1,35 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func pinnedSender(srv *httptest.Server, pins [][]byte) *Sender {
+	client := srv.Client()
+	transport := client.Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig = PinTLSConfig(transport.TLSClientConfig, pins)
+	client.Transport = transport
+	return NewSender(client, WithAllowInsecure())
+}
+
+func TestPinTLSConfig(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	goodPin := SPKISHA256(srv.Certificate())
+	if err := pinnedSender(srv, [][]byte{goodPin}).SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("expected matching pin to succeed, got %v", err)
+	}
+
+	badPin := make([]byte, len(goodPin))
+	err := pinnedSender(srv, [][]byte{badPin}).SendWebhook(context.Background(), srv.URL, testEvent())
+	if !errors.Is(err, ErrCertificatePinMismatch) {
+		t.Fatalf("expected pin mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package alerting
+
+import (
+	"fmt"
+	"html"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultShortSHALen is how many commit SHA characters are shown when
+	// no WithShortSHALen option is given.
+	DefaultShortSHALen = 7
+	// MinShortSHALen is the shortest abbreviation WithShortSHALen allows;
+	// anything shorter is too ambiguous to be useful.
+	MinShortSHALen = 4
+	// DefaultSlackPathLen is the longest file path Slack messages show
+	// before eliding its middle when no WithSlackPathLen option is given.
+	DefaultSlackPathLen = 100
+)
+
+// RenderOption customizes how an event is rendered into a human-facing
+// message.
+type RenderOption func(*renderConfig)
+
+type renderConfig struct {
+	shortSHALen     int
+	slackContext    []SlackContextPair
+	slackActions    bool
+	slackCallbackID string
+	slackPathLen    int
+	sanitizeContext func([]string) []string
+}
+
+func newRenderConfig(opts []RenderOption) renderConfig {
+	cfg := renderConfig{shortSHALen: DefaultShortSHALen, slackPathLen: DefaultSlackPathLen}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithShortSHALen sets how many commit SHA characters are shown. Zero
+// shows the full SHA; other values below MinShortSHALen are raised to it.
+func WithShortSHALen(n int) RenderOption {
+	return func(cfg *renderConfig) {
+		if n != 0 && n < MinShortSHALen {
+			n = MinShortSHALen
+		}
+		cfg.shortSHALen = n
+	}
+}
+
+// WithContextSanitizer runs fn over Event.ContextLines before they are
+// rendered, as a safeguard against producers that forget to redact them.
+// fn must return a new slice rather than modifying its argument. The
+// detect package's ContextSanitizer re-applies the detection rules.
+func WithContextSanitizer(fn func(lines []string) []string) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.sanitizeContext = fn
+	}
+}
+
+// WithSlackContext appends extra labelled context, such as the owning team
+// or last deploy, to Slack messages after the standard detail.
+func WithSlackContext(pairs ...SlackContextPair) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.slackContext = append(cfg.slackContext, pairs...)
+	}
+}
+
+// WithSlackActions adds Acknowledge and False positive buttons to Slack
+// messages. Slack posts clicks to the app's interactivity Request URL;
+// callbackID becomes the actions block_id so that handler can tell which
+// tripwire deployment the message came from.
+func WithSlackActions(callbackID string) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.slackActions = true
+		cfg.slackCallbackID = callbackID
+	}
+}
+
+// WithSlackPathLen sets the longest file path Slack messages show in full;
+// longer paths keep their first directory and basename and elide the
+// middle. Non-positive values never truncate. Webhook payloads always carry
+// the full path.
+func WithSlackPathLen(n int) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.slackPathLen = n
+	}
+}
+
+// shortSHA abbreviates sha in lowercase, as git prints it. SHAs already no
+// longer than the abbreviation are returned whole.
+func (cfg renderConfig) shortSHA(sha string) string {
+	sha = strings.ToLower(sha)
+	if cfg.shortSHALen > 0 && len(sha) > cfg.shortSHALen {
+		return sha[:cfg.shortSHALen]
+	}
+	return sha
+}
+
+// detailField is one labelled line of the standard finding detail.
+type detailField struct {
+	name  string
+	value string
+}
+
+// detailFields returns the finding detail shared by every human-facing
+// destination, in display order.
+func detailFields(event Event) []detailField {
+	fields := []detailField{
+		{name: "Repository", value: event.Repository},
+		{name: "Branch", value: event.Branch},
+		{name: "Commit", value: event.CommitSHA},
+		{name: "Rule", value: event.Rule},
+		{name: "File", value: event.Location()},
+		{name: "Author", value: event.Author},
+		{name: "Detected At", value: event.DetectedAt.UTC().Format(time.RFC3339)},
+	}
+	if event.SecretPreview != "" {
+		fields = append(fields, detailField{name: "Secret Preview", value: Redact(event.SecretPreview)})
+	}
+	if event.Occurrences > 1 {
+		fields = append(fields, detailField{name: "Occurrences", value: fmt.Sprintf("%d occurrences", event.Occurrences)})
+	}
+	for _, key := range slices.Sorted(maps.Keys(event.Fields)) {
+		fields = append(fields, detailField{name: key, value: event.Fields[key]})
+	}
+	return fields
+}
+
+// DetailStyle selects the markup FormatEventDetail renders.
+type DetailStyle int
+
+const (
+	// DetailMrkdwn renders "*Name:* `value`" lines in Slack mrkdwn, with
+	// long file paths shortened per WithSlackPathLen. Names and values are
+	// escaped so they cannot add mentions or links.
+	DetailMrkdwn DetailStyle = iota
+	// DetailPlain renders "Name: value" lines with no markup.
+	DetailPlain
+	// DetailHTML renders an escaped two-column table.
+	DetailHTML
+)
+
+// FormatEventDetail renders the standard finding detail (repository,
+// branch, commit, rule, file, author, detection time, and any preview,
+// occurrence count, or extra fields) in style, so every destination shows
+// the same lines. Unknown styles render as DetailPlain.
+func FormatEventDetail(event Event, style DetailStyle, opts ...RenderOption) string {
+	return formatDetail(event, style, newRenderConfig(opts))
+}
+
+func formatDetail(event Event, style DetailStyle, cfg renderConfig) string {
+	fields := detailFields(event)
+	if style == DetailHTML {
+		var b strings.Builder
+		b.WriteString("<table>")
+		for _, f := range fields {
+			fmt.Fprintf(&b, "<tr><th align=\"left\">%s</th><td><code>%s</code></td></tr>", html.EscapeString(f.name), html.EscapeString(f.value))
+		}
+		b.WriteString("</table>")
+		return b.String()
+	}
+	lines := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if style != DetailMrkdwn {
+			lines = append(lines, f.name+": "+f.value)
+			continue
+		}
+		if f.name == "File" {
+			// Keep the :line suffix intact while shortening the path.
+			suffix := strings.TrimPrefix(f.value, event.FilePath)
+			f.value = TruncatePath(event.FilePath, cfg.slackPathLen) + suffix
+		}
+		lines = append(lines, fmt.Sprintf("*%s:* `%s`", slackEscape(f.name), slackInlineCodeEscape(f.value)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TruncatePath shortens a slash-separated path longer than max by replacing
+// its middle directories with "...", e.g. "config/.../nested/settings.py".
+// The first directory is dropped only if it does not fit, and the basename
+// is always kept even when it alone exceeds max.
+func TruncatePath(p string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(p) <= max {
+		return p
+	}
+	segments := strings.Split(p, "/")
+	if len(segments) < 2 {
+		return p
+	}
+	base := len(segments) - 1
+	tail := segments[base]
+	for i := base - 1; i > 0; i-- {
+		candidate := segments[i] + "/" + tail
+		if utf8.RuneCountInString(segments[0]+"/.../"+candidate) > max {
+			break
+		}
+		tail = candidate
+	}
+	if short := segments[0] + "/.../" + tail; utf8.RuneCountInString(short) <= max {
+		return short
+	}
+	return ".../" + tail
+}
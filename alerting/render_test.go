@@ -0,0 +1,218 @@
+package alerting
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithShortSHALen(t *testing.T) {
+	event := testEvent()
+	event.CommitSHA = "0123456789abcdef0123456789abcdef01234567"
+
+	cases := []struct {
+		name string
+		opts []RenderOption
+		want string
+	}{
+		{name: "default", want: "(0123456)"},
+		{name: "twelve", opts: []RenderOption{WithShortSHALen(12)}, want: "(0123456789ab)"},
+		{name: "full", opts: []RenderOption{WithShortSHALen(0)}, want: "(" + event.CommitSHA + ")"},
+		{name: "clamped", opts: []RenderOption{WithShortSHALen(2)}, want: "(0123)"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := BuildSlackPayload(event, tc.opts...)
+			if !strings.HasSuffix(payload.Text, tc.want) {
+				t.Fatalf("expected summary ending in %q, got %q", tc.want, payload.Text)
+			}
+			discord := BuildDiscordPayload(event, tc.opts...)
+			if !strings.HasSuffix(discord.Content, tc.want) {
+				t.Fatalf("expected discord content ending in %q, got %q", tc.want, discord.Content)
+			}
+		})
+	}
+}
+
+func TestShortSHAEdgeLengths(t *testing.T) {
+	cfg := newRenderConfig(nil)
+	full := "0123456789ABCDEF0123456789abcdef01234567"
+	if got := cfg.shortSHA(full); got != "0123456" {
+		t.Fatalf("expected the short form 0123456, got %q", got)
+	}
+	for n := range DefaultShortSHALen + 2 {
+		sha := full[:n]
+		if got := cfg.shortSHA(sha); len(got) != min(n, DefaultShortSHALen) {
+			t.Errorf("shortSHA(%q) = %q", sha, got)
+		}
+	}
+}
+
+func TestFormatEventDetail(t *testing.T) {
+	event := testEvent()
+	event.LineNumber = 12
+	event.Fields = map[string]string{"team": "<platform>"}
+
+	if got, want := FormatEventDetail(event, DetailMrkdwn), BuildSlackPayload(event).Blocks[1].Text.Text; got != want {
+		t.Fatalf("expected the mrkdwn detail to match Slack:\n%s\ngot:\n%s", want, got)
+	}
+	plain := FormatEventDetail(event, DetailPlain)
+	if strings.ContainsAny(plain, "`*") || !strings.Contains(plain, "File: "+event.Location()) {
+		t.Fatalf("expected unmarked plain detail, got %q", plain)
+	}
+	html := FormatEventDetail(event, DetailHTML)
+	if !strings.Contains(html, "<th align=\"left\">team</th><td><code>&lt;platform&gt;</code></td>") {
+		t.Fatalf("expected an escaped HTML row, got %q", html)
+	}
+}
+
+func TestSlackDetailEscapesFields(t *testing.T) {
+	event := testEvent()
+	event.Fields = map[string]string{
+		"owner <!channel>": "<!here>",
+		"link":             "<https://evil.example|click>",
+		"tick":             "a`b",
+	}
+	detail := BuildSlackPayload(event).Blocks[1].Text.Text
+	for _, injected := range []string{"<!here>", "<!channel>", "<https://evil.example", "a`b"} {
+		if strings.Contains(detail, injected) {
+			t.Fatalf("expected %q to be escaped, got:\n%s", injected, detail)
+		}
+	}
+	for _, want := range []string{"*owner &lt;!channel&gt;:* `&lt;!here&gt;`", "*link:* `&lt;https://evil.example|click&gt;`", "*tick:* `a\u02cbb`"} {
+		if !strings.Contains(detail, want) {
+			t.Fatalf("expected %q in the detail, got:\n%s", want, detail)
+		}
+	}
+}
+
+func TestWithSlackContextRendersContextBlocks(t *testing.T) {
+	payload := BuildSlackPayload(testEvent(), WithSlackContext(
+		SlackContextPair{Label: "Team", Value: "platform-security"},
+		SlackContextPair{Label: "Channel Owner", Value: "<@U123>"},
+		SlackContextPair{Label: "Last Deploy", Value: "v1.4.2 & hotfix"},
+	))
+
+	extra := payload.Blocks[2:]
+	want := []string{
+		"*Team:* platform-security",
+		"*Channel Owner:* &lt;@U123&gt;",
+		"*Last Deploy:* v1.4.2 &amp; hotfix",
+	}
+	if len(extra) != len(want) {
+		t.Fatalf("expected %d context blocks, got %d", len(want), len(extra))
+	}
+	for i, block := range extra {
+		if block.Type != "context" || len(block.Elements) != 1 {
+			t.Fatalf("block %d: unexpected shape %+v", i, block)
+		}
+		if got := block.Elements[0].(SlackText).Text; got != want[i] {
+			t.Fatalf("block %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+
+	body, err := json.Marshal(extra[0])
+	if err != nil {
+		t.Fatalf("marshal block: %v", err)
+	}
+	if strings.Contains(string(body), `"text":{`) {
+		t.Fatalf("context block should not carry a text object: %s", body)
+	}
+}
+
+func TestWithSlackContextCapsBlocks(t *testing.T) {
+	pairs := make([]SlackContextPair, SlackMaxContextBlocks+5)
+	for i := range pairs {
+		pairs[i] = SlackContextPair{Label: "Key", Value: "value"}
+	}
+	payload := BuildSlackPayload(testEvent(), WithSlackContext(pairs...))
+
+	extra := payload.Blocks[2:]
+	if len(extra) != SlackMaxContextBlocks {
+		t.Fatalf("expected %d context blocks, got %d", SlackMaxContextBlocks, len(extra))
+	}
+	if got := extra[len(extra)-1].Elements[0].(SlackText).Text; got != "_+6 more_" {
+		t.Fatalf("expected overflow note, got %q", got)
+	}
+}
+
+func TestWithSlackActionsAddsTriageButtons(t *testing.T) {
+	event := testEvent()
+	payload := BuildSlackPayload(event, WithSlackActions("tripwire-prod"))
+
+	actions := payload.Blocks[len(payload.Blocks)-1]
+	if actions.Type != "actions" || actions.BlockID != "tripwire-prod" {
+		t.Fatalf("expected trailing actions block, got %+v", actions)
+	}
+	wantIDs := []string{SlackActionAcknowledge, SlackActionFalsePositive}
+	if len(actions.Elements) != len(wantIDs) {
+		t.Fatalf("expected %d buttons, got %d", len(wantIDs), len(actions.Elements))
+	}
+	for i, element := range actions.Elements {
+		button, ok := element.(SlackButton)
+		if !ok {
+			t.Fatalf("element %d is %T, want SlackButton", i, element)
+		}
+		if button.ActionID != wantIDs[i] {
+			t.Errorf("button %d action_id = %q, want %q", i, button.ActionID, wantIDs[i])
+		}
+		if button.Value != event.Fingerprint() {
+			t.Errorf("button %d value = %q, want the event fingerprint", i, button.Value)
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if !strings.Contains(string(body), `"action_id":"tripwire_false_positive"`) {
+		t.Fatalf("expected buttons in JSON: %s", body)
+	}
+}
+
+func TestSlackActionsAbsentByDefault(t *testing.T) {
+	for _, block := range BuildSlackPayload(testEvent()).Blocks {
+		if block.Type == "actions" {
+			t.Fatal("expected no actions block without WithSlackActions")
+		}
+	}
+}
+
+func TestTruncatePath(t *testing.T) {
+	tests := []struct {
+		path string
+		max  int
+		want string
+	}{
+		{"config/settings.py", 40, "config/settings.py"},
+		{"config/a/b/c/deeply/nested/settings.py", 36, "config/.../deeply/nested/settings.py"},
+		{"config/a/b/c/deeply/nested/settings.py", 22, "config/.../settings.py"},
+		{"services/payments/settings.py", 15, ".../settings.py"},
+		{"a/" + strings.Repeat("x", 30) + ".py", 10, ".../" + strings.Repeat("x", 30) + ".py"},
+		{"config/a/b/settings.py", 0, "config/a/b/settings.py"},
+	}
+	for _, tt := range tests {
+		if got := TruncatePath(tt.path, tt.max); got != tt.want {
+			t.Errorf("TruncatePath(%q, %d) = %q, want %q", tt.path, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestSlackTruncatesLongPathOnly(t *testing.T) {
+	event := testEvent()
+	event.FilePath = "services/" + strings.Repeat("nested/", 20) + "settings.py"
+	event.LineNumber = 7
+
+	detail := BuildSlackPayload(event, WithSlackPathLen(40)).Blocks[1].Text.Text
+	if !strings.Contains(detail, "`services/.../nested/nested/settings.py:7`") {
+		t.Fatalf("expected a middle-elided path with its basename, got %q", detail)
+	}
+	if got := BuildWebhookPayload(event).FilePath; got != event.FilePath {
+		t.Fatalf("expected the webhook to keep the full path, got %q", got)
+	}
+
+	short := BuildSlackPayload(testEvent(), WithSlackPathLen(40)).Blocks[1].Text.Text
+	if !strings.Contains(short, "`config/settings.py`") {
+		t.Fatalf("expected a short path unchanged, got %q", short)
+	}
+}
@@ -0,0 +1,167 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRetryBaseDelay  = 250 * time.Millisecond
+	DefaultRetryMaxDelay   = 10 * time.Second
+	DefaultRetryMultiplier = 2.0
+)
+
+// RetryPolicy configures exponential backoff between delivery attempts.
+// Zero delay and multiplier fields fall back to the Default* values.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Multiplier  float64
+	// MaxElapsed bounds the whole retry window: no retry is scheduled that
+	// would start later than MaxElapsed after the first attempt. Zero means
+	// no bound beyond MaxAttempts.
+	MaxElapsed time.Duration
+	// Jitter returns a random duration in [0, limit]. It must be safe for
+	// concurrent use; nil uses math/rand/v2. Tests inject a seeded source.
+	Jitter func(limit time.Duration) time.Duration
+}
+
+func (p RetryPolicy) attempts() int {
+	return max(p.MaxAttempts, 1)
+}
+
+// backoff returns the wait after the given failed attempt (1-based): a
+// uniformly random duration up to the exponential step capped at MaxDelay.
+// Full jitter keeps concurrent senders from retrying in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	base := orDefault(p.BaseDelay, DefaultRetryBaseDelay)
+	limit := orDefault(p.MaxDelay, DefaultRetryMaxDelay)
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultRetryMultiplier
+	}
+
+	delay := float64(base)
+	for i := 1; i < attempt && delay < float64(limit); i++ {
+		delay *= multiplier
+	}
+	capped := time.Duration(min(delay, float64(limit)))
+	if p.Jitter != nil {
+		return p.Jitter(capped)
+	}
+	return rand.N(capped + 1)
+}
+
+// exhausted reports whether a retry starting elapsed after the first
+// attempt would overrun MaxElapsed.
+func (p RetryPolicy) exhausted(elapsed time.Duration) bool {
+	return p.MaxElapsed > 0 && elapsed > p.MaxElapsed
+}
+
+// retryDelay picks the wait before the next attempt. The wait a 429
+// response asked for wins over the computed backoff, capped at MaxDelay.
+func (s *Sender) retryDelay(attempt int, err error) time.Duration {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return min(statusErr.RetryAfter, orDefault(s.Retry.MaxDelay, DefaultRetryMaxDelay))
+	}
+	return s.Retry.backoff(attempt)
+}
+
+// parseRetryAfter reads a Retry-After header in either delta-seconds or
+// HTTP-date form. Missing or malformed values return 0.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return 0
+}
+
+// rateLimitWait reads how long a 429 response asks callers to wait and
+// whether the limit is global to the token rather than one route. Discord's
+// X-RateLimit-Reset-After seconds win over an X-RateLimit-Reset epoch
+// time, which wins over Retry-After. The global flag comes from
+// X-RateLimit-Global, X-RateLimit-Scope, or Discord's JSON body.
+func rateLimitWait(header http.Header, body []byte, now time.Time) (wait time.Duration, global bool) {
+	global = strings.EqualFold(header.Get("X-RateLimit-Global"), "true") ||
+		strings.EqualFold(header.Get("X-RateLimit-Scope"), "global")
+	if !global && len(body) > 0 {
+		var discord struct {
+			Global bool `json:"global"`
+		}
+		global = json.Unmarshal(body, &discord) == nil && discord.Global
+	}
+
+	if seconds, ok := parseSeconds(header.Get("X-RateLimit-Reset-After")); ok {
+		return max(seconds, 0), global
+	}
+	if epoch, ok := parseSeconds(header.Get("X-RateLimit-Reset")); ok {
+		return max(time.Unix(0, 0).Add(epoch).Sub(now), 0), global
+	}
+	return parseRetryAfter(header.Get("Retry-After"), now), global
+}
+
+// parseSeconds parses fractional seconds such as "1.25".
+func parseSeconds(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// pauseForGlobalLimit holds every send until until, after a global 429.
+func (s *Sender) pauseForGlobalLimit(until time.Time) {
+	for {
+		current := s.globalLimitUntil.Load()
+		if until.UnixNano() <= current || s.globalLimitUntil.CompareAndSwap(current, until.UnixNano()) {
+			return
+		}
+	}
+}
+
+// waitForGlobalLimit blocks until a global rate limit has reset.
+func (s *Sender) waitForGlobalLimit(ctx context.Context) error {
+	until := s.globalLimitUntil.Load()
+	if until == 0 {
+		return nil
+	}
+	if wait := time.Unix(0, until).Sub(s.now()); wait > 0 {
+		return sleepContext(ctx, wait)
+	}
+	return nil
+}
+
+// retryableStatus reports whether a response status is transient.
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
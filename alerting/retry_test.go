@@ -0,0 +1,330 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetry(attempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: attempts, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+}
+
+func TestSendRetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.Retry = fastRetry(5)
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestSendDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.Retry = fastRetry(5)
+	err := s.SendWebhook(context.Background(), srv.URL, testEvent())
+	if responseStatus(err) != http.StatusBadRequest {
+		t.Fatalf("expected 400 error, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected exactly 1 attempt, got %d", got)
+	}
+}
+
+func TestSendGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.Retry = fastRetry(3)
+	if err := s.SendWebhook(context.Background(), srv.URL, testEvent()); responseStatus(err) != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestSendRetryHonorsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.Retry = RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	err := s.SendWebhook(ctx, srv.URL, testEvent())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+}
+
+func TestSendCanceledMidRequest(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.Retry = fastRetry(5)
+	err := s.SendWebhook(ctx, srv.URL, testEvent())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the error to unwrap to context.Canceled, got %v", err)
+	}
+	var sendErr *SendError
+	if !errors.As(err, &sendErr) || sendErr.Retryable {
+		t.Fatalf("expected a non-retryable SendError, got %#v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected cancellation to stop retries, got %d attempts", got)
+	}
+}
+
+func TestRetryPolicyBackoffIsCapped(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Multiplier: 2}
+	for attempt := 1; attempt <= 10; attempt++ {
+		step := min(100*time.Millisecond<<(attempt-1), time.Second)
+		got := p.backoff(attempt)
+		if got < 0 || got > step {
+			t.Fatalf("attempt %d: backoff %v outside [0, %v]", attempt, got, step)
+		}
+	}
+}
+
+func seededJitter(seed uint64) func(time.Duration) time.Duration {
+	r := rand.New(rand.NewPCG(seed, seed))
+	return func(limit time.Duration) time.Duration {
+		return time.Duration(r.Int64N(int64(limit) + 1))
+	}
+}
+
+func TestRetryPolicyFullJitter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: seededJitter(1)}
+	want := RetryPolicy{BaseDelay: p.BaseDelay, MaxDelay: p.MaxDelay, Jitter: seededJitter(1)}
+	var belowHalf bool
+	for attempt := 1; attempt <= 6; attempt++ {
+		step := min(100*time.Millisecond<<(attempt-1), time.Second)
+		got := p.backoff(attempt)
+		if got < 0 || got > step {
+			t.Fatalf("attempt %d: backoff %v outside [0, %v]", attempt, got, step)
+		}
+		if again := want.backoff(attempt); got != again {
+			t.Fatalf("attempt %d: expected the same seed to repeat %v, got %v", attempt, got, again)
+		}
+		belowHalf = belowHalf || got < step/2
+	}
+	if !belowHalf {
+		t.Fatal("expected full jitter to produce a delay below half the step")
+	}
+}
+
+func TestSendRetryStopsAtMaxElapsed(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusServiceUnavailable}
+	s := NewSender(doer)
+	s.Retry = RetryPolicy{
+		MaxAttempts: 10,
+		BaseDelay:   time.Hour,
+		MaxDelay:    time.Hour,
+		MaxElapsed:  time.Minute,
+		Jitter:      func(limit time.Duration) time.Duration { return limit },
+	}
+	err := s.SendWebhook(context.Background(), "https://example.com/hook", testEvent())
+	var sendErr *SendError
+	if !errors.As(err, &sendErr) || !sendErr.Retryable || sendErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a retryable 503 SendError, got %v", err)
+	}
+	if got := len(doer.requests); got != 1 {
+		t.Fatalf("expected MaxElapsed to stop after 1 attempt, got %d", got)
+	}
+}
+
+func TestSendHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.Retry = RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}
+	start := time.Now()
+	if err := s.SendSlack(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendSlack returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait for Retry-After, returned after %v", elapsed)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetryAfterCappedAtMaxDelay(t *testing.T) {
+	s := &Sender{Retry: RetryPolicy{MaxDelay: 50 * time.Millisecond}}
+	err := &statusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}
+	if got := s.retryDelay(1, err); got != 50*time.Millisecond {
+		t.Fatalf("expected delay capped at 50ms, got %v", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := fixedAlertTime()
+	tests := map[string]time.Duration{
+		"":     0,
+		"3":    3 * time.Second,
+		"-4":   0,
+		"soon": 0,
+		now.Add(90 * time.Second).Format(http.TimeFormat): 90 * time.Second,
+		now.Add(-time.Minute).Format(http.TimeFormat):     0,
+	}
+	for value, want := range tests {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := fixedAlertTime()
+	tests := []struct {
+		name       string
+		header     http.Header
+		body       string
+		wantWait   time.Duration
+		wantGlobal bool
+	}{
+		{
+			name:     "discord reset-after",
+			header:   http.Header{"X-Ratelimit-Reset-After": {"1.5"}, "Retry-After": {"9"}},
+			wantWait: 1500 * time.Millisecond,
+		},
+		{
+			name:       "discord global",
+			header:     http.Header{"X-Ratelimit-Reset-After": {"0.25"}, "X-Ratelimit-Global": {"true"}},
+			wantWait:   250 * time.Millisecond,
+			wantGlobal: true,
+		},
+		{
+			name:       "global from body",
+			header:     http.Header{"Retry-After": {"2"}},
+			body:       `{"message": "You are being rate limited.", "retry_after": 2, "global": true}`,
+			wantWait:   2 * time.Second,
+			wantGlobal: true,
+		},
+		{
+			name:     "reset epoch",
+			header:   http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(now.Add(4*time.Second).Unix(), 10)}},
+			wantWait: 4 * time.Second,
+		},
+		{
+			name:     "retry-after fallback",
+			header:   http.Header{"Retry-After": {"3"}},
+			wantWait: 3 * time.Second,
+		},
+		{
+			name:     "per-route scope",
+			header:   http.Header{"X-Ratelimit-Reset-After": {"1"}, "X-Ratelimit-Scope": {"user"}},
+			wantWait: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		wait, global := rateLimitWait(tt.header, []byte(tt.body), now)
+		if wait != tt.wantWait || global != tt.wantGlobal {
+			t.Errorf("%s: got wait %v global %t, want %v %t", tt.name, wait, global, tt.wantWait, tt.wantGlobal)
+		}
+	}
+}
+
+func TestSendWaitsForResetAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Reset-After", "0.2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	s.Retry = RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}
+	start := time.Now()
+	if err := s.SendDiscord(context.Background(), srv.URL, testEvent()); err != nil {
+		t.Fatalf("SendDiscord returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected to wait for X-RateLimit-Reset-After, returned after %v", elapsed)
+	}
+}
+
+func TestGlobalRateLimitPausesOtherSends(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Reset-After", "0.2")
+			w.Header().Set("X-RateLimit-Global", "true")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client(), WithAllowInsecure())
+	if err := s.SendDiscord(context.Background(), srv.URL, testEvent()); responseStatus(err) != http.StatusTooManyRequests {
+		t.Fatalf("expected the 429 without retries, got %v", err)
+	}
+	start := time.Now()
+	if err := s.SendDiscord(context.Background(), srv.URL+"/other", testEvent()); err != nil {
+		t.Fatalf("SendDiscord returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected the next send to wait out the global limit, returned after %v", elapsed)
+	}
+}
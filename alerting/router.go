@@ -0,0 +1,65 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Route sends events at or above MinSeverity to its Notifiers.
+type Route struct {
+	MinSeverity Severity
+	Notifiers   []Notifier
+}
+
+// Router dispatches each event to the first Route whose MinSeverity it
+// meets, so list routes from the most to the least severe. Events that
+// match no route go to Default.
+type Router struct {
+	Routes  []Route
+	Default []Notifier
+}
+
+func NewRouter(defaults ...Notifier) *Router {
+	return &Router{Default: defaults}
+}
+
+// Route appends a route for events at or above min and returns r.
+func (r *Router) Route(min Severity, notifiers ...Notifier) *Router {
+	r.Routes = append(r.Routes, Route{MinSeverity: min, Notifiers: notifiers})
+	return r
+}
+
+// Match returns the notifiers event is routed to.
+func (r *Router) Match(event Event) []Notifier {
+	for _, route := range r.Routes {
+		if event.Severity.AtLeast(route.MinSeverity) {
+			return route.Notifiers
+		}
+	}
+	return r.Default
+}
+
+// Notify delivers event concurrently to every matched notifier and joins
+// their errors.
+func (r *Router) Notify(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	notifiers := r.Match(event)
+	errs := make([]error, len(notifiers))
+	var wg sync.WaitGroup
+	for i, n := range notifiers {
+		if n == nil {
+			continue
+		}
+		wg.Go(func() {
+			if err := n.Notify(ctx, event); err != nil {
+				errs[i] = fmt.Errorf("notifier %d: %w", i, err)
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
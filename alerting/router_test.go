@@ -0,0 +1,62 @@
+package alerting
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+type countingNotifier struct {
+	calls atomic.Int32
+}
+
+func (n *countingNotifier) Notify(ctx context.Context, event Event) error {
+	n.calls.Add(1)
+	return nil
+}
+
+func TestRouterRoutesBySeverity(t *testing.T) {
+	pager := &countingNotifier{}
+	slack := &countingNotifier{}
+	fallback := &countingNotifier{}
+	r := NewRouter(fallback).
+		Route(SeverityCritical, pager, slack).
+		Route(SeverityLow, slack)
+
+	critical := testEvent()
+	critical.Severity = SeverityCritical
+	if err := r.Notify(context.Background(), critical); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if pager.calls.Load() != 1 || slack.calls.Load() != 1 {
+		t.Fatalf("expected critical to hit pager and slack, got pager=%d slack=%d", pager.calls.Load(), slack.calls.Load())
+	}
+
+	low := testEvent()
+	low.Severity = SeverityLow
+	if err := r.Notify(context.Background(), low); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if pager.calls.Load() != 1 {
+		t.Fatalf("expected low severity not to page, got %d pages", pager.calls.Load())
+	}
+	if slack.calls.Load() != 2 {
+		t.Fatalf("expected low severity to reach slack, got %d", slack.calls.Load())
+	}
+	if fallback.calls.Load() != 0 {
+		t.Fatalf("expected no default deliveries, got %d", fallback.calls.Load())
+	}
+}
+
+func TestRouterFallsThroughToDefault(t *testing.T) {
+	pager := &countingNotifier{}
+	fallback := &countingNotifier{}
+	r := NewRouter(fallback).Route(SeverityHigh, pager)
+
+	if err := r.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if pager.calls.Load() != 0 || fallback.calls.Load() != 1 {
+		t.Fatalf("expected a medium event to use the default, got pager=%d default=%d", pager.calls.Load(), fallback.calls.Load())
+	}
+}
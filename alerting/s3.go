@@ -0,0 +1,123 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3Putter is the part of *s3.Client used by S3Notifier.
+type s3Putter interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3Notifier writes each finding as a JSON object under a Hive-style
+// partition path so data lake tooling can prune by date and repository.
+type S3Notifier struct {
+	client s3Putter
+	Bucket string
+	// Prefix is prepended to every object key, e.g. "tripwire/findings".
+	Prefix string
+}
+
+// NewS3Notifier returns a notifier that puts objects through client,
+// normally an *s3.Client.
+func NewS3Notifier(client s3Putter, bucket string) *S3Notifier {
+	return &S3Notifier{client: client, Bucket: bucket}
+}
+
+func (n *S3Notifier) Notify(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	if n.client == nil {
+		return errors.New("s3 client is required")
+	}
+	if strings.TrimSpace(n.Bucket) == "" {
+		return errors.New("s3 bucket is required")
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return fmt.Errorf("generate object id: %w", err)
+	}
+	body, err := json.Marshal(BuildWebhookPayload(event))
+	if err != nil {
+		return fmt.Errorf("marshal s3 payload: %w", err)
+	}
+
+	_, err = n.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(n.Bucket),
+		Key:         aws.String(S3ObjectKey(n.Prefix, event, id)),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return canceledError("s3", ctxErr)
+		}
+		return s3SendError(err)
+	}
+	return nil
+}
+
+// s3RetryableCodes are S3 error codes for transient conditions.
+var s3RetryableCodes = map[string]bool{
+	"SlowDown":            true,
+	"Throttling":          true,
+	"ThrottlingException": true,
+	"RequestTimeout":      true,
+	"InternalError":       true,
+	"ServiceUnavailable":  true,
+}
+
+// s3SendError classifies a PutObject failure: 5xx, 429, throttling codes,
+// and errors with no response are retryable; other rejections, such as
+// AccessDenied or NoSuchBucket, are not.
+func s3SendError(err error) *SendError {
+	var (
+		status  int
+		code    string
+		httpErr interface{ HTTPStatusCode() int }
+		apiErr  interface{ ErrorCode() string }
+	)
+	if errors.As(err, &httpErr) {
+		status = httpErr.HTTPStatusCode()
+	}
+	if errors.As(err, &apiErr) {
+		code = apiErr.ErrorCode()
+	}
+	retryable := status >= 500 || status == http.StatusTooManyRequests || s3RetryableCodes[code] ||
+		(status == 0 && code == "")
+	return &SendError{Destination: "s3", StatusCode: status, Retryable: retryable, Err: fmt.Errorf("put s3 object: %w", err)}
+}
+
+// S3ObjectKey returns the partitioned key for an event:
+// [prefix/]year=YYYY/month=MM/day=DD/repo=<escaped repo>/<id>.json.
+func S3ObjectKey(prefix string, event Event, id string) string {
+	detected := event.DetectedAt.UTC()
+	partition := fmt.Sprintf("year=%04d/month=%02d/day=%02d/repo=%s",
+		detected.Year(), detected.Month(), detected.Day(), url.PathEscape(event.Repository))
+	return path.Join(strings.Trim(prefix, "/"), partition, id+".json")
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,105 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type fakeS3Putter struct {
+	inputs []*s3.PutObjectInput
+	bodies [][]byte
+	err    error
+}
+
+func (p *fakeS3Putter) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	body, _ := io.ReadAll(params.Body)
+	p.inputs = append(p.inputs, params)
+	p.bodies = append(p.bodies, body)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3NotifierPartitionedKey(t *testing.T) {
+	putter := &fakeS3Putter{}
+	n := NewS3Notifier(putter, "findings-lake")
+	n.Prefix = "tripwire/"
+
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if len(putter.inputs) != 1 {
+		t.Fatalf("expected one put, got %d", len(putter.inputs))
+	}
+	input := putter.inputs[0]
+	if aws.ToString(input.Bucket) != "findings-lake" {
+		t.Fatalf("unexpected bucket %q", aws.ToString(input.Bucket))
+	}
+	keyPattern := regexp.MustCompile(`^tripwire/year=2026/month=02/day=26/repo=acme%2Ftripwire/[0-9a-f-]{36}\.json$`)
+	if key := aws.ToString(input.Key); !keyPattern.MatchString(key) {
+		t.Fatalf("unexpected object key %q", key)
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(putter.bodies[0], &payload); err != nil {
+		t.Fatalf("decode object body: %v", err)
+	}
+	if payload.Rule != "aws-access-key-id" {
+		t.Fatalf("unexpected object body %+v", payload)
+	}
+}
+
+// fakeS3Error mimics the status and code an S3 operation error carries.
+type fakeS3Error struct {
+	status int
+	code   string
+}
+
+func (e *fakeS3Error) Error() string       { return fmt.Sprintf("%s (status %d)", e.code, e.status) }
+func (e *fakeS3Error) HTTPStatusCode() int { return e.status }
+func (e *fakeS3Error) ErrorCode() string   { return e.code }
+
+func TestS3NotifierClassifiesPutFailures(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"server error", &fakeS3Error{status: 503, code: "ServiceUnavailable"}, true},
+		{"throttled", &fakeS3Error{status: 503, code: "SlowDown"}, true},
+		{"too many requests", &fakeS3Error{status: 429}, true},
+		{"network", errors.New("dial tcp: connection refused"), true},
+		{"access denied", &fakeS3Error{status: 403, code: "AccessDenied"}, false},
+		{"no such bucket", &fakeS3Error{status: 404, code: "NoSuchBucket"}, false},
+		{"bad bucket name", &fakeS3Error{status: 400, code: "InvalidBucketName"}, false},
+	}
+	for _, tc := range cases {
+		n := NewS3Notifier(&fakeS3Putter{err: tc.err}, "findings-lake")
+		err := n.Notify(context.Background(), testEvent())
+		var sendErr *SendError
+		if !errors.As(err, &sendErr) {
+			t.Fatalf("%s: expected a SendError, got %v", tc.name, err)
+		}
+		if sendErr.Retryable != tc.retryable || sendErr.Destination != "s3" {
+			t.Errorf("%s: got %+v, want retryable %v", tc.name, sendErr, tc.retryable)
+		}
+	}
+}
+
+func TestS3NotifierPutFailure(t *testing.T) {
+	n := NewS3Notifier(&fakeS3Putter{err: errors.New("slow down")}, "findings-lake")
+	err := n.Notify(context.Background(), testEvent())
+	if err == nil || !strings.Contains(err.Error(), "slow down") {
+		t.Fatalf("expected put failure, got %v", err)
+	}
+}
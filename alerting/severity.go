@@ -0,0 +1,114 @@
+package alerting
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity ranks how damaging a leaked credential is. Severities order by
+// rank, so `event.Severity >= SeverityHigh` works once OrDefault has
+// resolved SeverityUnset. They marshal as their lowercase names.
+type Severity int
+
+const (
+	// SeverityUnset is the zero value and means DefaultSeverity.
+	SeverityUnset Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+
+	// DefaultSeverity applies to events that do not set one.
+	DefaultSeverity = SeverityMedium
+)
+
+var severityNames = map[Severity]string{
+	SeverityLow:      "low",
+	SeverityMedium:   "medium",
+	SeverityHigh:     "high",
+	SeverityCritical: "critical",
+}
+
+// ParseSeverity parses a severity name case-insensitively. The empty
+// string parses as SeverityUnset.
+func ParseSeverity(name string) (Severity, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return SeverityUnset, nil
+	}
+	for s, n := range severityNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return SeverityUnset, fmt.Errorf("unknown severity %q", name)
+}
+
+// String returns the lowercase name, "unset" for the zero value, or
+// Severity(n) for an out-of-range value.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	if s == SeverityUnset {
+		return "unset"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText encodes s as its name, with SeverityUnset as "".
+func (s Severity) MarshalText() ([]byte, error) {
+	if s == SeverityUnset {
+		return []byte{}, nil
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	parsed, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// Validate accepts the four known severities and SeverityUnset.
+func (s Severity) Validate() error {
+	if s < SeverityUnset || s > SeverityCritical {
+		return fmt.Errorf("unknown severity %s", s)
+	}
+	return nil
+}
+
+// OrDefault returns s, or DefaultSeverity when s is unset.
+func (s Severity) OrDefault() Severity {
+	if s == SeverityUnset {
+		return DefaultSeverity
+	}
+	return s
+}
+
+// AtLeast reports whether s, with unset meaning DefaultSeverity, ranks at
+// or above min.
+func (s Severity) AtLeast(min Severity) bool {
+	return s.OrDefault() >= min.OrDefault()
+}
+
+// SlackColor is the attachment color bar used for s: green, yellow,
+// orange, or red.
+func (s Severity) SlackColor() string {
+	switch s.OrDefault() {
+	case SeverityLow:
+		return "#36A64F"
+	case SeverityHigh:
+		return "#FF8800"
+	case SeverityCritical:
+		return "#D00000"
+	default:
+		return "#FFCC00"
+	}
+}
@@ -0,0 +1,111 @@
+package alerting
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSlackAttachmentColorBySeverity(t *testing.T) {
+	cases := map[Severity]string{
+		SeverityLow:      "#36A64F",
+		SeverityMedium:   "#FFCC00",
+		SeverityHigh:     "#FF8800",
+		SeverityCritical: "#D00000",
+		SeverityUnset:    "#FFCC00",
+	}
+	for severity, want := range cases {
+		event := testEvent()
+		event.Severity = severity
+		payload := BuildSlackPayload(event)
+		if len(payload.Attachments) != 1 {
+			t.Fatalf("%q: expected one attachment, got %d", severity, len(payload.Attachments))
+		}
+		if got := payload.Attachments[0].Color; got != want {
+			t.Errorf("%q: color = %s, want %s", severity, got, want)
+		}
+	}
+}
+
+func TestEventValidateSeverity(t *testing.T) {
+	event := testEvent()
+	event.Severity = Severity(9)
+	if err := event.Validate(); err == nil {
+		t.Fatal("expected unknown severity to fail validation")
+	}
+	event.Severity = SeverityUnset
+	if err := event.Validate(); err != nil {
+		t.Fatalf("expected unset severity to be valid, got %v", err)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	cases := map[string]Severity{
+		"low":      SeverityLow,
+		"Medium":   SeverityMedium,
+		" HIGH ":   SeverityHigh,
+		"cRiTiCaL": SeverityCritical,
+		"":         SeverityUnset,
+	}
+	for name, want := range cases {
+		got, err := ParseSeverity(name)
+		if err != nil || got != want {
+			t.Errorf("ParseSeverity(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseSeverity("urgent"); err == nil {
+		t.Fatal("expected an unknown name to fail")
+	}
+}
+
+func TestSeverityOrdering(t *testing.T) {
+	ordered := []Severity{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i] <= ordered[i-1] {
+			t.Fatalf("expected %s > %s", ordered[i], ordered[i-1])
+		}
+	}
+	if !SeverityCritical.AtLeast(SeverityHigh) || SeverityLow.AtLeast(SeverityMedium) {
+		t.Fatal("unexpected AtLeast ordering")
+	}
+	if !SeverityUnset.AtLeast(SeverityMedium) || SeverityUnset.AtLeast(SeverityHigh) {
+		t.Fatal("expected unset to rank as DefaultSeverity")
+	}
+}
+
+func TestSeverityJSONRoundTrip(t *testing.T) {
+	for _, severity := range []Severity{SeverityUnset, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical} {
+		data, err := json.Marshal(severity)
+		if err != nil {
+			t.Fatalf("marshal %s: %v", severity, err)
+		}
+		if severity != SeverityUnset && string(data) != `"`+severity.String()+`"` {
+			t.Fatalf("expected %s to marshal as its name, got %s", severity, data)
+		}
+		var got Severity
+		if err := json.Unmarshal(data, &got); err != nil || got != severity {
+			t.Fatalf("round trip of %s gave %v, %v", severity, got, err)
+		}
+	}
+
+	event := testEvent()
+	event.Severity = SeverityHigh
+	data, _ := json.Marshal(event)
+	if !strings.Contains(string(data), `"severity":"high"`) {
+		t.Fatalf("expected the event to carry severity high, got %s", data)
+	}
+	if err := json.Unmarshal([]byte(`"urgent"`), new(Severity)); err == nil {
+		t.Fatal("expected an unknown name to fail to unmarshal")
+	}
+}
+
+func TestWebhookPayloadCarriesSeverity(t *testing.T) {
+	event := testEvent()
+	if got := BuildWebhookPayload(event).Severity; got != "medium" {
+		t.Fatalf("expected default severity medium, got %q", got)
+	}
+	event.Severity = SeverityCritical
+	if got := BuildWebhookPayload(event).Severity; got != "critical" {
+		t.Fatalf("expected severity critical, got %q", got)
+	}
+}
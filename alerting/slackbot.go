@@ -0,0 +1,74 @@
+package alerting
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// slackAPIBaseURL is the Web API root; tests point it at a local server.
+var slackAPIBaseURL = "https://slack.com/api"
+
+// SlackBotMessage is a chat.postMessage request: the webhook message plus
+// the channel to post in.
+type SlackBotMessage struct {
+	Channel string `json:"channel"`
+	SlackPayload
+}
+
+// SlackAPIError is a logical error reported in a Slack Web API response
+// body, which Slack sends with HTTP 200.
+type SlackAPIError struct {
+	Method string
+	Code   string
+}
+
+func (e *SlackAPIError) Error() string {
+	return fmt.Sprintf("slack %s: %s", e.Method, e.Code)
+}
+
+// SendSlackBot posts the Slack message with a bot token, which unlike an
+// incoming webhook can target any channel the bot is in.
+func (s *Sender) SendSlackBot(ctx context.Context, botToken, channel string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	if strings.TrimSpace(botToken) == "" {
+		return errors.New("slack bot token is required")
+	}
+	if strings.TrimSpace(channel) == "" {
+		return errors.New("slack channel is required")
+	}
+	if s.suppressed(event) {
+		return nil
+	}
+	payload, err := BuildSlackPayloadTemplate(event, s.SlackTemplate, s.RenderOptions...)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	err = s.sendPayload(ctx, outboundRequest{
+		destination: "slack",
+		method:      http.MethodPost,
+		url:         slackAPIBaseURL + "/chat.postMessage",
+		contentType: "application/json; charset=utf-8",
+		header:      http.Header{"Authorization": []string{"Bearer " + botToken}},
+		event:       &event,
+		response:    &resp,
+	}, SlackBotMessage{Channel: channel, SlackPayload: payload})
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		return &SlackAPIError{Method: "chat.postMessage", Code: cmp.Or(resp.Error, "unknown_error")}
+	}
+	return nil
+}
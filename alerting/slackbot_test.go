@@ -0,0 +1,55 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func withSlackAPIURL(t *testing.T, url string) {
+	t.Helper()
+	previous := slackAPIBaseURL
+	slackAPIBaseURL = url
+	t.Cleanup(func() { slackAPIBaseURL = previous })
+}
+
+func TestSendSlackBotPostsMessage(t *testing.T) {
+	var got SlackBotMessage
+	var auth, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, path = r.Header.Get("Authorization"), r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.Write([]byte(`{"ok":true,"channel":"C123","ts":"1700000000.000100"}`))
+	}))
+	defer srv.Close()
+	withSlackAPIURL(t, srv.URL)
+
+	if err := NewSender(srv.Client(), WithAllowInsecure()).SendSlackBot(context.Background(), "xoxb-test", "#security", testEvent()); err != nil {
+		t.Fatalf("SendSlackBot returned error: %v", err)
+	}
+	if path != "/chat.postMessage" || auth != "Bearer xoxb-test" {
+		t.Fatalf("unexpected request path %q auth %q", path, auth)
+	}
+	if got.Channel != "#security" || len(got.Blocks) == 0 {
+		t.Fatalf("expected the channel and blocks, got %+v", got)
+	}
+}
+
+func TestSendSlackBotChecksOKField(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+	}))
+	defer srv.Close()
+	withSlackAPIURL(t, srv.URL)
+
+	err := NewSender(srv.Client(), WithAllowInsecure()).SendSlackBot(context.Background(), "xoxb-test", "#missing", testEvent())
+	var apiErr *SlackAPIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "channel_not_found" {
+		t.Fatalf("expected a channel_not_found SlackAPIError, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// snsPublisher is the part of *sns.Client used by SNSSender.
+type snsPublisher interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+// SNSSender publishes findings to Amazon SNS topics.
+type SNSSender struct {
+	client snsPublisher
+}
+
+// NewSNSSender returns a sender that publishes through client, normally an
+// *sns.Client.
+func NewSNSSender(client snsPublisher) *SNSSender {
+	return &SNSSender{client: client}
+}
+
+// SendSNS publishes the webhook payload to topicARN. The repository, rule,
+// and severity are also set as string message attributes so subscribers
+// can filter on them.
+func (s *SNSSender) SendSNS(ctx context.Context, topicARN string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	if s.client == nil {
+		return errors.New("sns client is required")
+	}
+	if strings.TrimSpace(topicARN) == "" {
+		return errors.New("sns topic ARN is required")
+	}
+
+	body, err := json.Marshal(BuildWebhookPayload(event))
+	if err != nil {
+		return fmt.Errorf("marshal sns payload: %w", err)
+	}
+	_, err = s.client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(topicARN),
+		Message:  aws.String(string(body)),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			"repository": snsStringAttribute(event.Repository),
+			"rule":       snsStringAttribute(event.Rule),
+			"severity":   snsStringAttribute(event.Severity.OrDefault().String()),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("publish sns message: %w", err)
+	}
+	return nil
+}
+
+func snsStringAttribute(value string) types.MessageAttributeValue {
+	return types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+}
@@ -0,0 +1,64 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+type fakeSNSPublisher struct {
+	inputs []*sns.PublishInput
+	err    error
+}
+
+func (p *fakeSNSPublisher) Publish(_ context.Context, params *sns.PublishInput, _ ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	p.inputs = append(p.inputs, params)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &sns.PublishOutput{MessageId: aws.String("msg-1")}, nil
+}
+
+func TestSendSNSSetsTopicAndAttributes(t *testing.T) {
+	publisher := &fakeSNSPublisher{}
+	event := testEvent()
+	event.Severity = SeverityHigh
+	arn := "arn:aws:sns:us-east-1:123456789012:tripwire-findings"
+	if err := NewSNSSender(publisher).SendSNS(context.Background(), arn, event); err != nil {
+		t.Fatalf("SendSNS returned error: %v", err)
+	}
+
+	if len(publisher.inputs) != 1 {
+		t.Fatalf("expected 1 publish, got %d", len(publisher.inputs))
+	}
+	input := publisher.inputs[0]
+	if aws.ToString(input.TopicArn) != arn {
+		t.Fatalf("expected topic %q, got %q", arn, aws.ToString(input.TopicArn))
+	}
+	want := map[string]string{"repository": "acme/tripwire", "rule": "aws-access-key-id", "severity": "high"}
+	for name, value := range want {
+		attr, ok := input.MessageAttributes[name]
+		if !ok || aws.ToString(attr.DataType) != "String" || aws.ToString(attr.StringValue) != value {
+			t.Fatalf("expected String attribute %s=%q, got %+v", name, value, attr)
+		}
+	}
+	var payload WebhookPayload
+	if err := json.Unmarshal([]byte(aws.ToString(input.Message)), &payload); err != nil {
+		t.Fatalf("message is not the webhook payload: %v", err)
+	}
+	if payload.CommitSHA != "abc1234def5678" {
+		t.Fatalf("unexpected message payload %+v", payload)
+	}
+}
+
+func TestSendSNSWrapsPublishError(t *testing.T) {
+	publisher := &fakeSNSPublisher{err: errors.New("throttled")}
+	err := NewSNSSender(publisher).SendSNS(context.Background(), "arn:aws:sns:us-east-1:123456789012:t", testEvent())
+	if err == nil || !errors.Is(err, publisher.err) {
+		t.Fatalf("expected the publish error to be wrapped, got %v", err)
+	}
+}
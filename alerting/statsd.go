@@ -0,0 +1,76 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// StatsDFindingsMetric is the counter incremented once per finding.
+const StatsDFindingsMetric = "tripwire.findings"
+
+// StatsDNotifier emits a DogStatsD counter for every finding it is given.
+type StatsDNotifier struct {
+	Addr   string
+	Dialer *net.Dialer
+}
+
+func NewStatsDNotifier(addr string) *StatsDNotifier {
+	return &StatsDNotifier{Addr: addr}
+}
+
+func (n *StatsDNotifier) Notify(ctx context.Context, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	if strings.TrimSpace(n.Addr) == "" {
+		return errors.New("statsd address is required")
+	}
+
+	dialer := n.Dialer
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+	conn, err := dialer.DialContext(ctx, "udp", n.Addr)
+	if err != nil {
+		return fmt.Errorf("dial statsd: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(BuildStatsDLine(event))); err != nil {
+		return fmt.Errorf("write statsd metric: %w", err)
+	}
+	return nil
+}
+
+// BuildStatsDLine renders the DogStatsD counter line for an event. An env
+// tag is added when the event has a non-empty "env" field.
+func BuildStatsDLine(event Event) string {
+	tags := []string{
+		"repo:" + statsDTagValue(event.Repository),
+		"rule:" + statsDTagValue(event.Rule),
+		"severity:" + event.Severity.OrDefault().String(),
+	}
+	if env := statsDTagValue(event.Fields["env"]); env != "" {
+		tags = append(tags, "env:"+env)
+	}
+	return fmt.Sprintf("%s:1|c|#%s", StatsDFindingsMetric, strings.Join(tags, ","))
+}
+
+// statsDTagValue lowercases a tag value and replaces characters DogStatsD
+// would otherwise treat as separators.
+func statsDTagValue(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r == '_', r == '-', r == ':', r == '.', r == '/':
+			return r
+		default:
+			return '_'
+		}
+	}, value)
+}
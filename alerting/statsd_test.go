@@ -0,0 +1,75 @@
+package alerting
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsDNotifierSendsCounter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen udp: %v", err)
+	}
+	defer conn.Close()
+
+	n := NewStatsDNotifier(conn.LocalAddr().String())
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	buf := make([]byte, 1024)
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("set deadline: %v", err)
+	}
+	size, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read packet: %v", err)
+	}
+	got := string(buf[:size])
+
+	if !strings.HasPrefix(got, "tripwire.findings:1|c|#") {
+		t.Fatalf("unexpected metric line %q", got)
+	}
+	for _, tag := range []string{"repo:acme/tripwire", "rule:aws-access-key-id", "severity:medium"} {
+		if !strings.Contains(got, tag) {
+			t.Fatalf("expected tag %q in %q", tag, got)
+		}
+	}
+}
+
+func TestStatsDTagValueSanitizes(t *testing.T) {
+	if got := statsDTagValue("Acme Corp|x,y#z"); got != "acme_corp_x_y_z" {
+		t.Fatalf("unexpected sanitized tag %q", got)
+	}
+}
+
+func TestBuildStatsDLineEnvTag(t *testing.T) {
+	event := testEvent()
+	if got := BuildStatsDLine(event); strings.Contains(got, "env:") {
+		t.Fatalf("expected no env tag without an env field, got %q", got)
+	}
+	event.Fields = map[string]string{"env": " Prod EU|1 "}
+	if got := BuildStatsDLine(event); !strings.HasSuffix(got, ",env:prod_eu_1") {
+		t.Fatalf("expected a sanitized env tag, got %q", got)
+	}
+}
+
+func TestBuildStatsDLineSeverityTag(t *testing.T) {
+	cases := map[Severity]string{
+		SeverityLow:      "severity:low",
+		SeverityMedium:   "severity:medium",
+		SeverityUnset:    "severity:medium",
+		SeverityHigh:     "severity:high",
+		SeverityCritical: "severity:critical",
+	}
+	for severity, want := range cases {
+		event := testEvent()
+		event.Severity = severity
+		if got := BuildStatsDLine(event); !strings.HasSuffix(got, ","+want) {
+			t.Errorf("BuildStatsDLine(%s) = %q, want a %q tag", severity, got, want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package alerting
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ScanSummaryTopRules is how many rules a scan summary lists.
+const ScanSummaryTopRules = 5
+
+// ScanStats summarizes one scan run for an end-of-run message. Build it
+// with Add as findings arrive, or fill the counts directly.
+type ScanStats struct {
+	Repository   string
+	FilesScanned int
+	StartedAt    time.Time
+	Duration     time.Duration
+	// BySeverity and ByRule count findings; Add keeps them in step.
+	BySeverity map[Severity]int
+	ByRule     map[string]int
+}
+
+// Add counts event under its severity, with unset meaning DefaultSeverity,
+// and its rule.
+func (s *ScanStats) Add(event Event) {
+	if s.BySeverity == nil {
+		s.BySeverity = make(map[Severity]int)
+	}
+	if s.ByRule == nil {
+		s.ByRule = make(map[string]int)
+	}
+	s.BySeverity[event.Severity.OrDefault()]++
+	s.ByRule[event.Rule]++
+}
+
+// Findings is the total number of findings across severities.
+func (s ScanStats) Findings() int {
+	total := 0
+	for _, n := range s.BySeverity {
+		total += n
+	}
+	return total
+}
+
+// RuleCount is one entry of a scan summary's top rules.
+type RuleCount struct {
+	Rule  string `json:"rule"`
+	Count int    `json:"count"`
+}
+
+// TopRules returns up to n rules by descending count, ties broken by name.
+func (s ScanStats) TopRules(n int) []RuleCount {
+	rules := make([]RuleCount, 0, len(s.ByRule))
+	for rule, count := range s.ByRule {
+		rules = append(rules, RuleCount{Rule: rule, Count: count})
+	}
+	slices.SortFunc(rules, func(a, b RuleCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Rule, b.Rule))
+	})
+	return rules[:min(n, len(rules))]
+}
+
+// severityBreakdown lists the non-zero severity counts, most severe first,
+// e.g. "critical: 2 · high: 1".
+func (s ScanStats) severityBreakdown() string {
+	var parts []string
+	for _, severity := range slices.Backward(slices.Sorted(maps.Keys(s.BySeverity))) {
+		if n := s.BySeverity[severity]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", severity, n))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// maxSeverity is the most severe severity with a finding.
+func (s ScanStats) maxSeverity() Severity {
+	highest := SeverityUnset
+	for severity, n := range s.BySeverity {
+		if n > 0 && severity > highest {
+			highest = severity
+		}
+	}
+	return highest
+}
+
+func (s ScanStats) summaryLine() string {
+	where := ""
+	if s.Repository != "" {
+		where = " in " + s.Repository
+	}
+	line := fmt.Sprintf("Scanned %d %s%s in %s", s.FilesScanned, plural(s.FilesScanned, "file", "files"), where, s.Duration.Round(time.Millisecond))
+	if n := s.Findings(); n > 0 {
+		return line + fmt.Sprintf(": %d %s detected", n, plural(n, "secret", "secrets"))
+	}
+	return line + ": no secrets detected"
+}
+
+// BuildScanSummary renders a "scan complete" Slack message: the file and
+// finding counts, a severity breakdown, and the top rules. A clean run
+// renders as a single green "no secrets detected" message.
+func BuildScanSummary(stats ScanStats) SlackPayload {
+	summary := stats.summaryLine()
+	payload := SlackPayload{
+		Text: ":mag: " + summary,
+		Blocks: []SlackBlock{{
+			Type: "section",
+			Text: SlackText{Type: "mrkdwn", Text: "*Scan Complete*\n" + slackEscape(summary)},
+		}},
+	}
+	color := SeverityLow.SlackColor()
+	if stats.Findings() > 0 {
+		lines := []string{"*By severity:* " + stats.severityBreakdown(), "*Top rules:*"}
+		for _, rc := range stats.TopRules(ScanSummaryTopRules) {
+			lines = append(lines, fmt.Sprintf("• `%s` (%d)", rc.Rule, rc.Count))
+		}
+		payload.Blocks = append(payload.Blocks, SlackBlock{
+			Type: "section",
+			Text: SlackText{Type: "mrkdwn", Text: strings.Join(lines, "\n")},
+		})
+		color = stats.maxSeverity().SlackColor()
+	}
+	payload.Attachments = []SlackAttachment{{Color: color}}
+	return payload
+}
+
+// ScanSummaryPayload is the generic JSON form of a scan summary.
+type ScanSummaryPayload struct {
+	Event         string         `json:"event"`
+	SchemaVersion string         `json:"schema_version"`
+	Repository    string         `json:"repository,omitempty"`
+	FilesScanned  int            `json:"files_scanned"`
+	Findings      int            `json:"findings"`
+	BySeverity    map[string]int `json:"by_severity"`
+	TopRules      []RuleCount    `json:"top_rules"`
+	StartedAt     string         `json:"started_at,omitempty"`
+	DurationMS    int64          `json:"duration_ms"`
+}
+
+func BuildScanSummaryPayload(stats ScanStats) ScanSummaryPayload {
+	payload := ScanSummaryPayload{
+		Event:         "scan.completed",
+		SchemaVersion: WebhookSchemaVersion,
+		Repository:    stats.Repository,
+		FilesScanned:  stats.FilesScanned,
+		Findings:      stats.Findings(),
+		BySeverity:    make(map[string]int, len(stats.BySeverity)),
+		TopRules:      stats.TopRules(ScanSummaryTopRules),
+		DurationMS:    stats.Duration.Milliseconds(),
+	}
+	for severity, n := range stats.BySeverity {
+		payload.BySeverity[severity.String()] = n
+	}
+	if !stats.StartedAt.IsZero() {
+		payload.StartedAt = stats.StartedAt.UTC().Format(time.RFC3339)
+	}
+	return payload
+}
+
+// SendSlackScanSummary posts BuildScanSummary to a Slack incoming webhook.
+func (s *Sender) SendSlackScanSummary(ctx context.Context, webhookURL string, stats ScanStats) error {
+	return s.sendJSON(ctx, "slack", webhookURL, nil, BuildScanSummary(stats))
+}
+
+// SendScanSummary posts BuildScanSummaryPayload, signed like SendWebhook.
+func (s *Sender) SendScanSummary(ctx context.Context, webhookURL string, stats ScanStats) error {
+	return s.sendPayload(ctx, outboundRequest{
+		destination: "webhook",
+		method:      http.MethodPost,
+		url:         webhookURL,
+		contentType: "application/json",
+		sign:        true,
+	}, BuildScanSummaryPayload(stats))
+}
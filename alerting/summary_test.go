@@ -0,0 +1,81 @@
+package alerting
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mixedScanStats() ScanStats {
+	stats := ScanStats{Repository: "acme/tripwire", FilesScanned: 120, Duration: 1500 * time.Millisecond, StartedAt: fixedAlertTime()}
+	for _, f := range []struct {
+		rule     string
+		severity Severity
+	}{
+		{"aws-access-key-id", SeverityCritical},
+		{"aws-access-key-id", SeverityCritical},
+		{"github-token", SeverityHigh},
+		{"high-entropy-string", SeverityUnset},
+		{"high-entropy-string", SeverityLow},
+	} {
+		event := testEvent()
+		event.Rule, event.Severity = f.rule, f.severity
+		stats.Add(event)
+	}
+	return stats
+}
+
+func TestBuildScanSummaryMixedSeverities(t *testing.T) {
+	payload := BuildScanSummary(mixedScanStats())
+
+	if want := "Scanned 120 files in acme/tripwire in 1.5s: 5 secrets detected"; !strings.Contains(payload.Text, want) {
+		t.Fatalf("expected %q in the summary, got %q", want, payload.Text)
+	}
+	if len(payload.Blocks) != 2 {
+		t.Fatalf("expected a header and a breakdown block, got %d", len(payload.Blocks))
+	}
+	breakdown := payload.Blocks[1].Text.Text
+	if !strings.Contains(breakdown, "critical: 2 · high: 1 · medium: 1 · low: 1") {
+		t.Fatalf("unexpected severity breakdown %q", breakdown)
+	}
+	if i, j := strings.Index(breakdown, "`aws-access-key-id` (2)"), strings.Index(breakdown, "`github-token` (1)"); i < 0 || j < i {
+		t.Fatalf("expected top rules by count, got %q", breakdown)
+	}
+	if got := payload.Attachments[0].Color; got != SeverityCritical.SlackColor() {
+		t.Fatalf("expected the critical color, got %s", got)
+	}
+}
+
+func TestBuildScanSummaryCleanRun(t *testing.T) {
+	payload := BuildScanSummary(ScanStats{FilesScanned: 1, Duration: 20 * time.Millisecond})
+	if want := "Scanned 1 file in 20ms: no secrets detected"; !strings.Contains(payload.Text, want) {
+		t.Fatalf("expected %q, got %q", want, payload.Text)
+	}
+	if len(payload.Blocks) != 1 {
+		t.Fatalf("expected only the header block, got %d", len(payload.Blocks))
+	}
+	if got := payload.Attachments[0].Color; got != SeverityLow.SlackColor() {
+		t.Fatalf("expected the green color, got %s", got)
+	}
+}
+
+func TestBuildScanSummaryPayload(t *testing.T) {
+	data, err := json.Marshal(BuildScanSummaryPayload(mixedScanStats()))
+	if err != nil {
+		t.Fatalf("marshal summary: %v", err)
+	}
+	var got ScanSummaryPayload
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal summary: %v", err)
+	}
+	if got.Event != "scan.completed" || got.Findings != 5 || got.FilesScanned != 120 || got.DurationMS != 1500 {
+		t.Fatalf("unexpected summary %+v", got)
+	}
+	if got.BySeverity["critical"] != 2 || got.BySeverity["medium"] != 1 {
+		t.Fatalf("unexpected severity counts %v", got.BySeverity)
+	}
+	if len(got.TopRules) != 3 || got.TopRules[0] != (RuleCount{Rule: "aws-access-key-id", Count: 2}) {
+		t.Fatalf("unexpected top rules %v", got.TopRules)
+	}
+}
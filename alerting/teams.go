@@ -0,0 +1,96 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+)
+
+// TeamsPayload is the Microsoft Teams incoming webhook message envelope.
+type TeamsPayload struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment carries an Adaptive Card inside a Teams message.
+type TeamsAttachment struct {
+	ContentType string            `json:"contentType"`
+	Content     TeamsAdaptiveCard `json:"content"`
+}
+
+// TeamsAdaptiveCard is the subset of the Adaptive Card schema tripwire uses.
+type TeamsAdaptiveCard struct {
+	Schema  string             `json:"$schema"`
+	Type    string             `json:"type"`
+	Version string             `json:"version"`
+	Body    []TeamsCardElement `json:"body"`
+}
+
+// TeamsCardElement is a TextBlock or FactSet element in an Adaptive Card.
+type TeamsCardElement struct {
+	Type   string      `json:"type"`
+	Text   string      `json:"text,omitempty"`
+	Weight string      `json:"weight,omitempty"`
+	Size   string      `json:"size,omitempty"`
+	Color  string      `json:"color,omitempty"`
+	Wrap   bool        `json:"wrap,omitempty"`
+	Facts  []TeamsFact `json:"facts,omitempty"`
+}
+
+// TeamsFact is one title/value row in a FactSet.
+type TeamsFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+func BuildTeamsPayload(event Event, opts ...RenderOption) TeamsPayload {
+	cfg := newRenderConfig(opts)
+	return TeamsPayload{
+		Type: "message",
+		Attachments: []TeamsAttachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: TeamsAdaptiveCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body: []TeamsCardElement{
+						{
+							Type:   "TextBlock",
+							Text:   "🚨 " + summaryLine(event, opts...),
+							Weight: "Bolder",
+							Size:   "Medium",
+							Color:  "Attention",
+							Wrap:   true,
+						},
+						{
+							Type:  "FactSet",
+							Facts: teamsFacts(event, cfg),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// teamsFacts lists detailFields as facts, with the commit abbreviated the
+// way the summary shows it.
+func teamsFacts(event Event, cfg renderConfig) []TeamsFact {
+	fields := detailFields(event)
+	facts := make([]TeamsFact, 0, len(fields))
+	for _, f := range fields {
+		if f.name == "Commit" {
+			f.value = cfg.shortSHA(f.value)
+		}
+		facts = append(facts, TeamsFact{Title: f.name, Value: f.value})
+	}
+	return facts
+}
+
+func (s *Sender) SendTeams(ctx context.Context, webhookURL string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	return s.sendJSON(ctx, "teams", webhookURL, &event, BuildTeamsPayload(event, s.RenderOptions...))
+}
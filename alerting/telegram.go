@@ -0,0 +1,76 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// telegramAPIBaseURL is the Bot API root; tests point it at a local server.
+var telegramAPIBaseURL = "https://api.telegram.org"
+
+// TelegramMessage is the sendMessage request body.
+type TelegramMessage struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+func BuildTelegramMessage(chatID string, event Event, opts ...RenderOption) TelegramMessage {
+	lines := []string{
+		"🚨 *Secret Leak Detected*",
+		escapeMarkdownV2(summaryLine(event, opts...)),
+		"",
+	}
+	for _, f := range detailFields(event) {
+		lines = append(lines, fmt.Sprintf("*%s:* `%s`", escapeMarkdownV2(f.name), escapeMarkdownV2(f.value)))
+	}
+	return TelegramMessage{
+		ChatID:                chatID,
+		Text:                  strings.Join(lines, "\n"),
+		ParseMode:             "MarkdownV2",
+		DisableWebPagePreview: true,
+	}
+}
+
+func (s *Sender) SendTelegram(ctx context.Context, botToken, chatID string, event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event = s.prepareEvent(event)
+	if strings.TrimSpace(botToken) == "" {
+		return errors.New("telegram bot token is required")
+	}
+	if strings.ContainsAny(botToken, "/?#%") {
+		return errors.New("invalid telegram bot token")
+	}
+	if strings.TrimSpace(chatID) == "" {
+		return errors.New("telegram chat ID is required")
+	}
+
+	return s.sendPayload(ctx, outboundRequest{
+		destination: "telegram",
+		method:      http.MethodPost,
+		url:         telegramAPIBaseURL + "/bot" + botToken + "/sendMessage",
+		contentType: "application/json",
+		secretInURL: botToken,
+		event:       &event,
+	}, BuildTelegramMessage(chatID, event, s.RenderOptions...))
+}
+
+// escapeMarkdownV2 backslash-escapes every character Telegram reserves in
+// MarkdownV2 text, including inside code spans.
+func escapeMarkdownV2(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if strings.ContainsRune("\\_*[]()~`>#+-=|{}.!", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEscapeMarkdownV2(t *testing.T) {
+	tests := map[string]string{
+		"plain":          "plain",
+		"aws_access_key": `aws\_access\_key`,
+		"*bold*":         `\*bold\*`,
+		"config.env":     `config\.env`,
+		"feature-branch": `feature\-branch`,
+		"(a) [b] {c}":    `\(a\) \[b\] \{c\}`,
+		`back\slash`:     `back\\slash`,
+		"~`>#+=|!":       "\\~\\`\\>\\#\\+\\=\\|\\!",
+		"ünïcode_ok":     `ünïcode\_ok`,
+	}
+	for in, want := range tests {
+		if got := escapeMarkdownV2(in); got != want {
+			t.Errorf("escapeMarkdownV2(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSendTelegramPostsMarkdownV2Message(t *testing.T) {
+	var gotPath string
+	var got TelegramMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	previous := telegramAPIBaseURL
+	telegramAPIBaseURL = srv.URL
+	t.Cleanup(func() { telegramAPIBaseURL = previous })
+
+	event := testEvent()
+	event.FilePath = "config/prod.env"
+	if err := NewSender(srv.Client(), WithAllowInsecure()).SendTelegram(context.Background(), "123:abc", "-100200", event); err != nil {
+		t.Fatalf("SendTelegram returned error: %v", err)
+	}
+
+	if gotPath != "/bot123:abc/sendMessage" {
+		t.Fatalf("unexpected path %q", gotPath)
+	}
+	if got.ChatID != "-100200" {
+		t.Fatalf("expected chat_id -100200, got %q", got.ChatID)
+	}
+	if got.ParseMode != "MarkdownV2" {
+		t.Fatalf("expected MarkdownV2 parse mode, got %q", got.ParseMode)
+	}
+	if !strings.Contains(got.Text, "*File:* `config/prod\\.env`") {
+		t.Fatalf("expected escaped file path in %q", got.Text)
+	}
+}
+
+func TestSendTelegramRedactsTokenFromErrors(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	previous := telegramAPIBaseURL
+	telegramAPIBaseURL = srv.URL
+	t.Cleanup(func() { telegramAPIBaseURL = previous })
+
+	err := NewSender(nil).SendTelegram(context.Background(), "123:secret-token", "42", testEvent())
+	if err == nil {
+		t.Fatal("expected transport error")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Fatalf("bot token leaked into error: %v", err)
+	}
+}
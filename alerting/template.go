@@ -0,0 +1,52 @@
+package alerting
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// SlackTemplateFuncs are the helpers available to Slack message templates.
+// shortSHA abbreviates a commit SHA the same way the built-in format does.
+func SlackTemplateFuncs() template.FuncMap {
+	return slackTemplateFuncs(newRenderConfig(nil))
+}
+
+func slackTemplateFuncs(cfg renderConfig) template.FuncMap {
+	return template.FuncMap{
+		"shortSHA": cfg.shortSHA,
+	}
+}
+
+// ParseSlackTemplate parses a text/template for Slack messages with
+// SlackTemplateFuncs registered. The template is executed with the Event.
+func ParseSlackTemplate(text string) (*template.Template, error) {
+	return template.New("slack").Funcs(SlackTemplateFuncs()).Parse(text)
+}
+
+// BuildSlackPayloadTemplate renders event through tmpl into a single
+// mrkdwn section. A nil tmpl falls back to BuildSlackPayload.
+func BuildSlackPayloadTemplate(event Event, tmpl *template.Template, opts ...RenderOption) (SlackPayload, error) {
+	if tmpl == nil {
+		return BuildSlackPayload(event, opts...), nil
+	}
+	// Rebind the helpers so render options such as WithShortSHALen apply.
+	bound, err := tmpl.Clone()
+	if err != nil {
+		return SlackPayload{}, fmt.Errorf("clone slack template: %w", err)
+	}
+	bound.Funcs(slackTemplateFuncs(newRenderConfig(opts)))
+
+	var b strings.Builder
+	if err := bound.Execute(&b, event); err != nil {
+		return SlackPayload{}, fmt.Errorf("render slack template: %w", err)
+	}
+	text := strings.TrimSpace(b.String())
+	return SlackPayload{
+		Text: text,
+		Blocks: []SlackBlock{{
+			Type: "section",
+			Text: SlackText{Type: "mrkdwn", Text: truncateRunes(text, SlackSectionTextLimit)},
+		}},
+	}, nil
+}
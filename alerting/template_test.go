@@ -0,0 +1,75 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBuildSlackPayloadTemplateCustomFormat(t *testing.T) {
+	tmpl, err := ParseSlackTemplate(`*{{.Rule}}* leaked in {{.Repository}}@{{shortSHA .CommitSHA}} ({{.FilePath}})`)
+	if err != nil {
+		t.Fatalf("ParseSlackTemplate returned error: %v", err)
+	}
+	event := testEvent()
+	payload, err := BuildSlackPayloadTemplate(event, tmpl)
+	if err != nil {
+		t.Fatalf("BuildSlackPayloadTemplate returned error: %v", err)
+	}
+
+	want := "*aws-access-key-id* leaked in acme/tripwire@abc1234 (config/settings.py)"
+	if payload.Text != want {
+		t.Fatalf("expected %q, got %q", want, payload.Text)
+	}
+	if len(payload.Blocks) != 1 || payload.Blocks[0].Text.Text != want {
+		t.Fatalf("unexpected blocks %+v", payload.Blocks)
+	}
+	body, _ := json.Marshal(payload)
+	if strings.Contains(string(body), event.Author) {
+		t.Fatalf("template omitted the author but payload contains it: %s", body)
+	}
+}
+
+func TestBuildSlackPayloadTemplateHonorsRenderOptions(t *testing.T) {
+	tmpl, err := ParseSlackTemplate(`{{shortSHA .CommitSHA}}`)
+	if err != nil {
+		t.Fatalf("ParseSlackTemplate returned error: %v", err)
+	}
+	payload, err := BuildSlackPayloadTemplate(testEvent(), tmpl, WithShortSHALen(10))
+	if err != nil {
+		t.Fatalf("BuildSlackPayloadTemplate returned error: %v", err)
+	}
+	if payload.Text != "abc1234def" {
+		t.Fatalf("unexpected short SHA %q", payload.Text)
+	}
+}
+
+func TestBuildSlackPayloadTemplateNilFallsBack(t *testing.T) {
+	payload, err := BuildSlackPayloadTemplate(testEvent(), nil)
+	if err != nil {
+		t.Fatalf("BuildSlackPayloadTemplate returned error: %v", err)
+	}
+	if payload.Text != BuildSlackPayload(testEvent()).Text {
+		t.Fatalf("expected built-in format, got %q", payload.Text)
+	}
+}
+
+func TestSendSlackTemplateErrorDoesNotSend(t *testing.T) {
+	tmpl, err := ParseSlackTemplate(`{{.Missing.Field}}`)
+	if err != nil {
+		t.Fatalf("ParseSlackTemplate returned error: %v", err)
+	}
+	doer := &fakeDoer{status: http.StatusOK}
+	s := NewSender(doer)
+	s.SlackTemplate = tmpl
+
+	err = s.SendSlack(context.Background(), "https://example.com/hook", testEvent())
+	if err == nil || !strings.Contains(err.Error(), "render slack template") {
+		t.Fatalf("expected template error, got %v", err)
+	}
+	if len(doer.requests) != 0 {
+		t.Fatal("expected no request after a template error")
+	}
+}
@@ -0,0 +1,34 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+)
+
+// TestEvent returns a clearly fake finding for smoke-testing a destination.
+// It passes Validate and is marked Synthetic so receivers can filter it out.
+func TestEvent() Event {
+	return newTestEvent(RealClock{})
+}
+
+func newTestEvent(clock Clock) Event {
+	return Event{
+		Repository: "tripwire/test",
+		Branch:     "main",
+		CommitSHA:  "0000000000000000000000000000000000000000",
+		Rule:       "test-rule",
+		FilePath:   "tripwire/test.txt",
+		Author:     "tripwire@example.com",
+		DetectedAt: clock.Now().UTC(),
+		Synthetic:  true,
+	}
+}
+
+// SendTest delivers TestEvent through notifier's normal payload path, to
+// confirm a newly configured destination receives findings.
+func (s *Sender) SendTest(ctx context.Context, notifier Notifier) error {
+	if err := notifier.Notify(ctx, newTestEvent(clockOrDefault(s.Clock))); err != nil {
+		return fmt.Errorf("send test event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTestEventIsValidAndSynthetic(t *testing.T) {
+	event := TestEvent()
+	if err := event.Validate(); err != nil {
+		t.Fatalf("expected TestEvent to validate, got %v", err)
+	}
+	if !event.Synthetic {
+		t.Fatal("expected TestEvent to be marked synthetic")
+	}
+	if !BuildWebhookPayload(event).Synthetic {
+		t.Fatal("expected webhook payload to carry the synthetic marker")
+	}
+	if BuildWebhookPayload(testEvent()).Synthetic {
+		t.Fatal("expected real events to not be marked synthetic")
+	}
+}
+
+func TestSendTestUsesNotifierPayloadPath(t *testing.T) {
+	var got WebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(srv.Client(), WithAllowInsecure())
+	sender.Clock = ClockFunc(fixedAlertTime)
+	notifier := &WebhookNotifier{Sender: sender, WebhookURL: srv.URL}
+	if err := sender.SendTest(context.Background(), notifier); err != nil {
+		t.Fatalf("SendTest returned error: %v", err)
+	}
+	if !got.Synthetic || got.Repository != "tripwire/test" || got.Rule != "test-rule" {
+		t.Fatalf("expected the synthetic test event, got %+v", got)
+	}
+}
+
+func TestSendTestReturnsNotifierError(t *testing.T) {
+	sender := NewSender(&fakeDoer{status: http.StatusInternalServerError})
+	sender.Retry = fastRetry(1)
+	notifier := &WebhookNotifier{Sender: sender, WebhookURL: "https://hooks.example.com/endpoint"}
+	if err := sender.SendTest(context.Background(), notifier); err == nil {
+		t.Fatal("expected the notifier's error to be returned")
+	}
+}
@@ -0,0 +1,127 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultThrottleWindow is how long Throttle coalesces findings sharing a
+// repository and rule before sending one summary.
+const DefaultThrottleWindow = 5 * time.Minute
+
+// Throttle caps alert storms at one send per (repository, rule) per Window.
+// The first finding in a window is sent when the window closes with
+// Occurrences set to the number of findings it absorbed. Unlike
+// Deduplicator, findings in different files still coalesce.
+type Throttle struct {
+	Window      time.Duration
+	Destination Destination
+	// OnError is called for summaries whose send fails after the window
+	// elapses; nil ignores them.
+	OnError func(event Event, err error)
+	// Clock times the windows; nil uses RealClock.
+	Clock Clock
+
+	mu      sync.Mutex
+	pending map[throttleKey]*pendingThrottle
+	closed  bool
+	// windows counts scheduled window callbacks that have not finished, so
+	// Close can wait for a summary already being sent.
+	windows sync.WaitGroup
+}
+
+type throttleKey struct {
+	repository string
+	rule       string
+}
+
+type pendingThrottle struct {
+	event Event
+	stop  func() bool
+}
+
+func NewThrottle(window time.Duration, destination Destination) *Throttle {
+	return &Throttle{Window: window, Destination: destination}
+}
+
+// Add counts event toward its (repository, rule) window, starting a window
+// if none is open. It returns ErrSenderClosed after Close.
+func (t *Throttle) Add(event Event) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	if t.Destination == nil {
+		return errors.New("throttle destination is required")
+	}
+	key := throttleKey{repository: event.Repository, rule: event.Rule}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return ErrSenderClosed
+	}
+	if t.pending == nil {
+		t.pending = make(map[throttleKey]*pendingThrottle)
+	}
+	if p, ok := t.pending[key]; ok {
+		p.event.Occurrences++
+		return nil
+	}
+
+	event.Occurrences = 1
+	t.windows.Add(1)
+	t.pending[key] = &pendingThrottle{
+		event: event,
+		stop: afterFunc(t.Clock, orDefault(t.Window, DefaultThrottleWindow), func() {
+			defer t.windows.Done()
+			t.emit(context.Background(), key)
+		}),
+	}
+	return nil
+}
+
+// Close stops accepting events and sends every pending summary, joining
+// their errors. It returns once any summary a window already started
+// sending has finished.
+func (t *Throttle) Close(ctx context.Context) error {
+	t.mu.Lock()
+	t.closed = true
+	keys := make([]throttleKey, 0, len(t.pending))
+	for key := range t.pending {
+		keys = append(keys, key)
+	}
+	t.mu.Unlock()
+
+	var errs []error
+	for _, key := range keys {
+		if err := t.emit(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", key.repository, key.rule, err))
+		}
+	}
+	t.windows.Wait()
+	return errors.Join(errs...)
+}
+
+func (t *Throttle) emit(ctx context.Context, key throttleKey) error {
+	t.mu.Lock()
+	p, ok := t.pending[key]
+	if ok {
+		if p.stop() {
+			// The window callback will not run, so end it here.
+			t.windows.Done()
+		}
+		delete(t.pending, key)
+	}
+	t.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	err := t.Destination(ctx, p.event)
+	if err != nil && t.OnError != nil {
+		t.OnError(p.event, err)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottleCoalescesRepoAndRule(t *testing.T) {
+	var mu sync.Mutex
+	var sent []Event
+	th := NewThrottle(time.Hour, func(ctx context.Context, event Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, event)
+		return nil
+	})
+
+	for _, file := range []string{"a.py", "b.py", "c.py"} {
+		event := testEvent()
+		event.FilePath = file
+		if err := th.Add(event); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+	if err := th.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 send, got %d", len(sent))
+	}
+	if sent[0].Occurrences != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", sent[0].Occurrences)
+	}
+	if detail := slackDetail(sent[0], newRenderConfig(nil)); !strings.Contains(detail, "3 occurrences") {
+		t.Fatalf("expected the Slack detail to note the count, got %q", detail)
+	}
+	if err := th.Add(testEvent()); !errors.Is(err, ErrSenderClosed) {
+		t.Fatalf("expected ErrSenderClosed after Close, got %v", err)
+	}
+}
+
+func TestThrottleSendsWhenWindowElapses(t *testing.T) {
+	clock := newFakeClock()
+	var sent []Event
+	th := NewThrottle(time.Minute, func(ctx context.Context, event Event) error {
+		sent = append(sent, event)
+		return nil
+	})
+	th.Clock = clock
+	other := testEvent()
+	other.Rule = "github-token"
+	for _, event := range []Event{testEvent(), other} {
+		if err := th.Add(event); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+
+	clock.Advance(time.Minute - time.Nanosecond)
+	if len(sent) != 0 {
+		t.Fatalf("expected nothing sent before the window closes, got %d", len(sent))
+	}
+	clock.Advance(time.Nanosecond)
+	if len(sent) != 2 {
+		t.Fatalf("expected one summary per rule, got %d", len(sent))
+	}
+	for _, event := range sent {
+		if event.Occurrences != 1 {
+			t.Fatalf("expected distinct rules not to coalesce, got %d occurrences", event.Occurrences)
+		}
+	}
+}
+
+func TestThrottleCloseWaitsForRunningSummary(t *testing.T) {
+	clock := newFakeClock()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished atomic.Bool
+	th := NewThrottle(time.Minute, func(ctx context.Context, event Event) error {
+		close(started)
+		<-release
+		finished.Store(true)
+		return nil
+	})
+	th.Clock = clock
+	if err := th.Add(testEvent()); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	go clock.Advance(time.Minute)
+	<-started
+
+	closed := make(chan error, 1)
+	go func() { closed <- th.Close(context.Background()) }()
+	select {
+	case err := <-closed:
+		t.Fatalf("Close returned %v while a summary was still sending", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	if err := <-closed; err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if !finished.Load() {
+		t.Fatal("expected Close to return after the running summary finished")
+	}
+}
+
+func TestBuildSlackPayloadNotesOccurrences(t *testing.T) {
+	event := testEvent()
+	if got := BuildSlackPayload(event).Blocks[0].Text.Text; got != "*Secret Leak Detected*" {
+		t.Fatalf("expected no count for a single finding, got %q", got)
+	}
+	event.Occurrences = 3
+	if got := BuildSlackPayload(event).Blocks[0].Text.Text; got != "*Secret Leak Detected* (3 occurrences)" {
+		t.Fatalf("expected the occurrence count in the heading, got %q", got)
+	}
+}
+
+func TestThrottledOccurrencesReachWebhookPayload(t *testing.T) {
+	event := testEvent()
+	event.Occurrences = 3
+	if got := BuildWebhookPayload(event).Occurrences; got != 3 {
+		t.Fatalf("expected 3 occurrences in the webhook payload, got %d", got)
+	}
+	if got := BuildWebhookPayload(testEvent()).Occurrences; got != 0 {
+		t.Fatalf("expected a single finding to omit occurrences, got %d", got)
+	}
+}
@@ -0,0 +1,64 @@
+package alerting
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SendSpanName names the span wrapping each event or batch delivery.
+const SendSpanName = "tripwire.alert.send"
+
+const tracerName = "main/alerting"
+
+func (s *Sender) tracer() trace.Tracer {
+	tp := s.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// startSendSpan starts the delivery span for out. Its attributes carry only
+// the webhook host, never the path or query.
+func (s *Sender) startSendSpan(ctx context.Context, out outboundRequest) (context.Context, trace.Span) {
+	host, _ := redactedTarget(out)
+	attrs := []attribute.KeyValue{
+		attribute.String("tripwire.destination", out.destination),
+		attribute.String("server.address", host),
+	}
+	if out.event != nil {
+		attrs = append(attrs,
+			attribute.String("tripwire.repository", out.event.Repository),
+			attribute.String("tripwire.rule", out.event.Rule),
+		)
+	}
+	return s.tracer().Start(ctx, SendSpanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+// endSendSpan records the outcome of the delivery and ends span. Errors
+// are recorded with their URL redacted as in the logs, so a query string
+// or a credential in the path never reaches the trace backend.
+func endSendSpan(span trace.Span, out outboundRequest, err error) {
+	if err != nil {
+		err = redactURLError(err, out.secretInURL)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// recordAttempt notes the attempt count and, when a response arrived, its
+// status on the delivery span in ctx.
+func recordAttempt(ctx context.Context, attempt, status int) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("tripwire.attempts", attempt))
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+}
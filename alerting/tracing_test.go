@@ -0,0 +1,84 @@
+package alerting
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSendRecordsSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	doer := &fakeDoer{status: http.StatusServiceUnavailable}
+	s := NewSender(doer)
+	s.Retry = fastRetry(2)
+	s.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	if err := s.SendWebhook(context.Background(), "https://hooks.example.com/tripwire?token=x", testEvent()); err == nil {
+		t.Fatal("expected the send to fail")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != SendSpanName {
+		t.Fatalf("unexpected span name %q", span.Name())
+	}
+	if span.Status().Code != codes.Error || len(span.Events()) == 0 {
+		t.Fatalf("expected the error to be recorded, got status %v", span.Status())
+	}
+	got := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		got[kv.Key] = kv.Value
+	}
+	want := map[attribute.Key]attribute.Value{
+		"tripwire.destination":      attribute.StringValue("webhook"),
+		"server.address":            attribute.StringValue("hooks.example.com"),
+		"tripwire.repository":       attribute.StringValue("acme/tripwire"),
+		"tripwire.rule":             attribute.StringValue("aws-access-key-id"),
+		"tripwire.attempts":         attribute.IntValue(2),
+		"http.response.status_code": attribute.IntValue(http.StatusServiceUnavailable),
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("attribute %s = %v, want %v", key, got[key].Emit(), value.Emit())
+		}
+	}
+}
+
+func TestSendSpanRedactsErrorURL(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	s := NewSender(transportErrDoer{})
+	s.Retry = fastRetry(1)
+	s.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	if err := s.SendWebhook(context.Background(), "https://hooks.example.com/tripwire?token=s3cr3t", testEvent()); err == nil {
+		t.Fatal("expected the send to fail")
+	}
+	span := recorder.Ended()[0]
+	if strings.Contains(span.Status().Description, "s3cr3t") {
+		t.Fatalf("query token leaked into the span status: %q", span.Status().Description)
+	}
+	events := span.Events()
+	if len(events) != 1 || events[0].Name != "exception" {
+		t.Fatalf("expected one exception event, got %+v", events)
+	}
+	for _, kv := range events[0].Attributes {
+		if strings.Contains(kv.Value.Emit(), "s3cr3t") {
+			t.Fatalf("query token leaked into span event attribute %s: %s", kv.Key, kv.Value.Emit())
+		}
+	}
+}
+
+func TestSendWithoutTracerProvider(t *testing.T) {
+	if err := NewSender(&fakeDoer{status: http.StatusOK}).SendSlack(context.Background(), "https://hooks.slack.com/services/T/B/X", testEvent()); err != nil {
+		t.Fatalf("expected the global no-op provider to be harmless, got %v", err)
+	}
+}
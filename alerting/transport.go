@@ -0,0 +1,48 @@
+package alerting
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Default per-phase timeouts used by NewHTTPClient for unset fields. They
+// fail fast on unreachable hosts while leaving room for a receiver that is
+// slow to process the alert but still responding.
+const (
+	DefaultDialTimeout           = 5 * time.Second
+	DefaultTLSHandshakeTimeout   = 5 * time.Second
+	DefaultResponseHeaderTimeout = 10 * time.Second
+	DefaultExpectContinueTimeout = 1 * time.Second
+)
+
+// TransportTimeouts bounds each phase of an outbound request. Zero fields
+// use the package defaults.
+type TransportTimeouts struct {
+	Dial           time.Duration
+	TLSHandshake   time.Duration
+	ResponseHeader time.Duration
+	ExpectContinue time.Duration
+}
+
+// NewHTTPClient returns a client whose transport applies the given
+// per-phase timeouts instead of a single overall deadline.
+func NewHTTPClient(timeouts TransportTimeouts) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   orDefault(timeouts.Dial, DefaultDialTimeout),
+		KeepAlive: 30 * time.Second,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.TLSHandshakeTimeout = orDefault(timeouts.TLSHandshake, DefaultTLSHandshakeTimeout)
+	transport.ResponseHeaderTimeout = orDefault(timeouts.ResponseHeader, DefaultResponseHeaderTimeout)
+	transport.ExpectContinueTimeout = orDefault(timeouts.ExpectContinue, DefaultExpectContinueTimeout)
+	return &http.Client{Transport: transport}
+}
+
+func orDefault(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
@@ -0,0 +1,48 @@
+package alerting
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClientResponseHeaderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer fast.Close()
+
+	s := NewSender(NewHTTPClient(TransportTimeouts{ResponseHeader: 50 * time.Millisecond}), WithAllowInsecure())
+
+	started := time.Now()
+	err := s.SendWebhook(context.Background(), slow.URL, testEvent())
+	if err == nil || !strings.Contains(err.Error(), "timeout awaiting response headers") {
+		t.Fatalf("expected response header timeout, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Fatalf("expected the header timeout to trip quickly, took %s", elapsed)
+	}
+
+	if err := s.SendWebhook(context.Background(), fast.URL, testEvent()); err != nil {
+		t.Fatalf("expected fast server to succeed, got %v", err)
+	}
+}
+
+func TestNewHTTPClientDefaults(t *testing.T) {
+	transport := NewHTTPClient(TransportTimeouts{}).Transport.(*http.Transport)
+	if transport.TLSHandshakeTimeout != DefaultTLSHandshakeTimeout ||
+		transport.ResponseHeaderTimeout != DefaultResponseHeaderTimeout ||
+		transport.ExpectContinueTimeout != DefaultExpectContinueTimeout {
+		t.Fatalf("unexpected default timeouts: %+v", transport)
+	}
+}
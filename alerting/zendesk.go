@@ -0,0 +1,159 @@
+package alerting
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// zendeskSubdomainPattern matches a single DNS label, so a subdomain cannot
+// redirect the request to another host or path.
+var zendeskSubdomainPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ZendeskPriority maps a severity onto Zendesk's ticket priorities.
+func ZendeskPriority(severity Severity) string {
+	switch severity.OrDefault() {
+	case SeverityCritical:
+		return "urgent"
+	case SeverityHigh:
+		return "high"
+	case SeverityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// ZendeskSender files findings as tickets through the Zendesk Tickets API.
+type ZendeskSender struct {
+	Sender    *Sender
+	Subdomain string
+	Email     string
+	APIToken  string
+	GroupID   int64
+	// BaseURL overrides https://<subdomain>.zendesk.com, mainly for tests.
+	BaseURL string
+}
+
+type zendeskTicketRequest struct {
+	Ticket zendeskTicket `json:"ticket"`
+}
+
+type zendeskTicket struct {
+	Subject  string         `json:"subject"`
+	Comment  zendeskComment `json:"comment"`
+	Priority string         `json:"priority"`
+	GroupID  int64          `json:"group_id,omitempty"`
+	Tags     []string       `json:"tags"`
+}
+
+type zendeskComment struct {
+	HTMLBody string `json:"html_body"`
+	Public   bool   `json:"public"`
+}
+
+type zendeskTicketResponse struct {
+	Ticket struct {
+		ID int64 `json:"id"`
+	} `json:"ticket"`
+}
+
+type zendeskErrorResponse struct {
+	Error       string          `json:"error"`
+	Description string          `json:"description"`
+	Details     json.RawMessage `json:"details"`
+}
+
+func buildZendeskTicket(event Event, groupID int64) zendeskTicketRequest {
+	var body strings.Builder
+	body.WriteString("<p><strong>Secret Leak Detected</strong></p><ul>")
+	for _, f := range detailFields(event) {
+		fmt.Fprintf(&body, "<li><strong>%s:</strong> <code>%s</code></li>", f.name, html.EscapeString(f.value))
+	}
+	body.WriteString("</ul>")
+
+	return zendeskTicketRequest{
+		Ticket: zendeskTicket{
+			Subject:  summaryLine(event),
+			Comment:  zendeskComment{HTMLBody: body.String()},
+			Priority: ZendeskPriority(event.Severity),
+			GroupID:  groupID,
+			Tags:     []string{"tripwire", zendeskTag(event.Rule), zendeskTag(event.Repository)},
+		},
+	}
+}
+
+// CreateTicket opens a ticket for the finding and returns its ID. An event
+// the Sender's allowlist or baseline suppresses returns (0, nil) without
+// sending anything; a created ticket always has a non-zero ID, since a
+// response without one is an error.
+func (z *ZendeskSender) CreateTicket(ctx context.Context, event Event) (int64, error) {
+	if err := event.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid event: %w", err)
+	}
+	if strings.TrimSpace(z.Email) == "" || strings.TrimSpace(z.APIToken) == "" {
+		return 0, errors.New("zendesk email and API token are required")
+	}
+	baseURL := strings.TrimRight(z.BaseURL, "/")
+	if baseURL == "" {
+		if strings.TrimSpace(z.Subdomain) == "" {
+			return 0, errors.New("zendesk subdomain is required")
+		}
+		if !zendeskSubdomainPattern.MatchString(z.Subdomain) {
+			return 0, fmt.Errorf("invalid zendesk subdomain %q", z.Subdomain)
+		}
+		baseURL = fmt.Sprintf("https://%s.zendesk.com", z.Subdomain)
+	}
+	sender := senderOrDefault(z.Sender)
+	event = sender.prepareEvent(event)
+	if sender.suppressed(event) {
+		return 0, nil
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(z.Email + "/token:" + z.APIToken))
+	var created zendeskTicketResponse
+	err := sender.sendPayload(ctx, outboundRequest{
+		destination: "zendesk",
+		method:      http.MethodPost,
+		url:         baseURL + "/api/v2/tickets.json",
+		contentType: "application/json",
+		header:      http.Header{"Authorization": []string{"Basic " + credentials}},
+		event:       &event,
+		response:    &created,
+	}, buildZendeskTicket(event, z.GroupID))
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnprocessableEntity {
+		var apiErr zendeskErrorResponse
+		if json.Unmarshal(statusErr.body, &apiErr) == nil && apiErr.Error != "" {
+			return 0, fmt.Errorf("zendesk rejected ticket: %s: %s %s: %w", apiErr.Error, apiErr.Description, apiErr.Details, err)
+		}
+		return 0, fmt.Errorf("zendesk rejected ticket: %w", err)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if created.Ticket.ID == 0 {
+		return 0, errors.New("zendesk response missing ticket id")
+	}
+	return created.Ticket.ID, nil
+}
+
+// zendeskTag lowercases a value and replaces characters Zendesk does not
+// accept in tags.
+func zendeskTag(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, value)
+}
@@ -0,0 +1,159 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestZendeskCreateTicket(t *testing.T) {
+	var got zendeskTicketRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/tickets.json" {
+			t.Fatalf("unexpected path %q", r.URL.Path)
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "sec@example.com/token" || pass != "api-token" {
+			t.Fatalf("unexpected basic auth %q/%q", user, pass)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ticket":{"id":35436,"subject":"x"}}`))
+	}))
+	defer srv.Close()
+
+	z := &ZendeskSender{
+		Sender:   NewSender(srv.Client(), WithAllowInsecure()),
+		Email:    "sec@example.com",
+		APIToken: "api-token",
+		GroupID:  42,
+		BaseURL:  srv.URL,
+	}
+	id, err := z.CreateTicket(context.Background(), testEvent())
+	if err != nil {
+		t.Fatalf("CreateTicket returned error: %v", err)
+	}
+	if id != 35436 {
+		t.Fatalf("expected ticket id 35436, got %d", id)
+	}
+	if got.Ticket.GroupID != 42 || got.Ticket.Priority != "normal" {
+		t.Fatalf("unexpected ticket metadata: %+v", got.Ticket)
+	}
+	if !strings.Contains(got.Ticket.Comment.HTMLBody, "<code>aws-access-key-id</code>") {
+		t.Fatalf("expected rule in html body, got %q", got.Ticket.Comment.HTMLBody)
+	}
+	wantTags := []string{"tripwire", "aws-access-key-id", "acme_tripwire"}
+	if strings.Join(got.Ticket.Tags, ",") != strings.Join(wantTags, ",") {
+		t.Fatalf("expected tags %v, got %v", wantTags, got.Ticket.Tags)
+	}
+}
+
+func TestZendeskCreateTicketValidationError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error":"RecordInvalid","description":"Record validation errors","details":{"group":[{"description":"Group is invalid"}]}}`))
+	}))
+	defer srv.Close()
+
+	z := &ZendeskSender{Sender: NewSender(srv.Client(), WithAllowInsecure()), Email: "sec@example.com", APIToken: "api-token", BaseURL: srv.URL}
+	_, err := z.CreateTicket(context.Background(), testEvent())
+	if err == nil || !strings.Contains(err.Error(), "RecordInvalid") || !strings.Contains(err.Error(), "Group is invalid") {
+		t.Fatalf("expected validation details in error, got %v", err)
+	}
+}
+
+func TestZendeskCreateTicketSkipsSuppressedEvents(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusCreated}
+	sender := NewSender(doer)
+	sender.Allowlist = NewAllowlist(AllowRule{Rule: "aws-access-key-id"})
+	z := &ZendeskSender{Sender: sender, Subdomain: "acme", Email: "sec@example.com", APIToken: "api-token"}
+	id, err := z.CreateTicket(context.Background(), testEvent())
+	if err != nil || id != 0 {
+		t.Fatalf("expected a suppressed event to return (0, nil), got %d, %v", id, err)
+	}
+	if len(doer.requests) != 0 {
+		t.Fatalf("expected no request for a suppressed event, got %d", len(doer.requests))
+	}
+}
+
+func TestZendeskCreateTicketRejectsMissingTicketID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ticket":{}}`))
+	}))
+	defer srv.Close()
+
+	z := &ZendeskSender{Sender: NewSender(srv.Client(), WithAllowInsecure()), Email: "sec@example.com", APIToken: "api-token", BaseURL: srv.URL}
+	if id, err := z.CreateTicket(context.Background(), testEvent()); err == nil {
+		t.Fatalf("expected an error for a response without a ticket id, got id %d", id)
+	}
+}
+
+func TestZendeskCreateTicketRejectsInvalidSubdomain(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusCreated}
+	for _, subdomain := range []string{"evil.com/x?", "acme.example", "-acme", "Acme", "acme@evil"} {
+		z := &ZendeskSender{Sender: NewSender(doer), Subdomain: subdomain, Email: "sec@example.com", APIToken: "api-token"}
+		if _, err := z.CreateTicket(context.Background(), testEvent()); err == nil || !strings.Contains(err.Error(), "invalid zendesk subdomain") {
+			t.Errorf("subdomain %q: expected invalid subdomain error, got %v", subdomain, err)
+		}
+	}
+	if len(doer.requests) != 0 {
+		t.Fatalf("expected no requests, got %d", len(doer.requests))
+	}
+}
+
+func TestZendeskCreateTicketRetriesServerErrors(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ticket":{"id":7}}`))
+	}))
+	defer srv.Close()
+
+	sender := NewSender(srv.Client(), WithAllowInsecure())
+	sender.Retry = fastRetry(2)
+	z := &ZendeskSender{Sender: sender, Email: "sec@example.com", APIToken: "api-token", BaseURL: srv.URL}
+	id, err := z.CreateTicket(context.Background(), testEvent())
+	if err != nil || id != 7 {
+		t.Fatalf("expected ticket 7 after a retry, got %d, %v", id, err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestZendeskCreateTicketRejectsInsecureBaseURL(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusCreated}
+	z := &ZendeskSender{Sender: NewSender(doer), Email: "sec@example.com", APIToken: "api-token", BaseURL: "http://zendesk.example.com"}
+	if _, err := z.CreateTicket(context.Background(), testEvent()); err == nil {
+		t.Fatal("expected an error for an http base URL")
+	}
+	if len(doer.requests) != 0 {
+		t.Fatalf("expected no requests, got %d", len(doer.requests))
+	}
+}
+
+func TestZendeskPriority(t *testing.T) {
+	cases := map[Severity]string{
+		SeverityLow:      "low",
+		SeverityMedium:   "normal",
+		SeverityUnset:    "normal",
+		SeverityHigh:     "high",
+		SeverityCritical: "urgent",
+	}
+	for severity, want := range cases {
+		if got := ZendeskPriority(severity); got != want {
+			t.Errorf("ZendeskPriority(%s) = %q, want %q", severity, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package detect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileStamp identifies one version of a file for checkpointing: a file
+// whose size and modification time are unchanged is assumed unchanged.
+type fileStamp struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+func stampOf(info fs.FileInfo) fileStamp {
+	return fileStamp{Size: info.Size(), ModTime: info.ModTime().UTC()}
+}
+
+// checkpoint is the set of files a scan has finished, keyed by their
+// slash-separated path relative to the scan root.
+type checkpoint struct {
+	Files map[string]fileStamp `json:"files"`
+}
+
+// readCheckpoint loads the checkpoint at path. A missing file is an empty
+// checkpoint, so the first run of a resumable scan needs no setup.
+func readCheckpoint(path string) (*checkpoint, error) {
+	cp := &checkpoint{Files: make(map[string]fileStamp)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s: %w", path, err)
+	}
+	if cp.Files == nil {
+		cp.Files = make(map[string]fileStamp)
+	}
+	return cp, nil
+}
+
+// done reports whether rel was scanned at exactly this version.
+func (cp *checkpoint) done(rel string, stamp fileStamp) bool {
+	prev, ok := cp.Files[rel]
+	return ok && prev.Size == stamp.Size && prev.ModTime.Equal(stamp.ModTime)
+}
+
+// write replaces the checkpoint at path through a temporary file, so an
+// interrupted write never leaves a truncated checkpoint behind.
+func (cp *checkpoint) write(path string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
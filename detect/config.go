@@ -0,0 +1,80 @@
+package detect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"main/alerting"
+)
+
+// ruleFile is the on-disk rules document. JSON documents parse too, since
+// JSON is valid YAML.
+type ruleFile struct {
+	Rules []ruleConfig `yaml:"rules"`
+}
+
+type ruleConfig struct {
+	ID          string            `yaml:"id"`
+	Description string            `yaml:"description"`
+	Regex       string            `yaml:"regex"`
+	Severity    alerting.Severity `yaml:"severity"`
+	AllowPaths  []string          `yaml:"allow_paths"`
+}
+
+// LoadRules parses a YAML or JSON rules document of the form
+//
+//	rules:
+//	  - id: internal-api-key
+//	    description: Internal API key
+//	    regex: '\bik_[0-9a-f]{32}\b'
+//	    severity: high
+//	    allow_paths: ["docs/**"]
+//
+// and compiles each rule. Errors name the offending rule's id.
+func LoadRules(r io.Reader) ([]Rule, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	var file ruleFile
+	if err := dec.Decode(&file); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("rules document is empty")
+		}
+		return nil, fmt.Errorf("decode rules: %w", err)
+	}
+
+	rules := make([]Rule, 0, len(file.Rules))
+	seen := make(map[string]bool, len(file.Rules))
+	for i, cfg := range file.Rules {
+		id := strings.TrimSpace(cfg.ID)
+		if id == "" {
+			return nil, fmt.Errorf("rule %d: id is required", i+1)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("rule %q: duplicate id", id)
+		}
+		seen[id] = true
+		if cfg.Regex == "" {
+			return nil, fmt.Errorf("rule %q: regex is required", id)
+		}
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: compile regex: %w", id, err)
+		}
+		if err := cfg.Severity.Validate(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", id, err)
+		}
+		rules = append(rules, Rule{
+			ID:          id,
+			Description: cfg.Description,
+			Regexp:      re,
+			Severity:    cfg.Severity.OrDefault(),
+			AllowPaths:  cfg.AllowPaths,
+		})
+	}
+	return rules, nil
+}
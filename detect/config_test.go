@@ -0,0 +1,72 @@
+package detect
+
+import (
+	"strings"
+	"testing"
+
+	"main/alerting"
+)
+
+func TestLoadRulesYAML(t *testing.T) {
+	doc := `
+rules:
+  - id: internal-api-key
+    description: Internal API key
+    regex: '\bik_[0-9a-f]{32}\b'
+    severity: high
+    allow_paths: ["docs/**"]
+  - id: legacy-token
+    regex: 'lt-[A-Z]{10}'
+`
+	rules, err := LoadRules(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("LoadRules returned error: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].ID != "internal-api-key" || rules[0].Severity != alerting.SeverityHigh {
+		t.Fatalf("unexpected first rule %+v", rules[0])
+	}
+	if got := rules[0].Match("key=ik_" + strings.Repeat("0a", 16)); len(got) != 1 {
+		t.Fatalf("expected the compiled regex to match, got %+v", got)
+	}
+	if rules[0].AppliesTo("docs/setup.md") || !rules[0].AppliesTo("src/app.go") {
+		t.Fatal("expected allow_paths to exempt docs/** only")
+	}
+	if rules[1].Severity != alerting.DefaultSeverity {
+		t.Fatalf("expected the default severity, got %q", rules[1].Severity)
+	}
+}
+
+func TestLoadRulesJSON(t *testing.T) {
+	doc := `{"rules": [{"id": "legacy-token", "regex": "lt-[A-Z]{10}", "severity": "low"}]}`
+	rules, err := LoadRules(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("LoadRules returned error: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Severity != alerting.SeverityLow {
+		t.Fatalf("unexpected rules %+v", rules)
+	}
+}
+
+func TestLoadRulesErrors(t *testing.T) {
+	tests := map[string]string{
+		"invalid regex":  "rules:\n  - id: broken-rule\n    regex: '(unclosed'\n",
+		"missing id":     "rules:\n  - regex: 'x'\n",
+		"duplicate id":   "rules:\n  - id: a\n    regex: 'x'\n  - id: a\n    regex: 'y'\n",
+		"bad severity":   "rules:\n  - id: a\n    regex: 'x'\n    severity: urgent\n",
+		"unknown field":  "rules:\n  - id: a\n    regex: 'x'\n    pattern: 'y'\n",
+		"empty document": "",
+	}
+	for name, doc := range tests {
+		if _, err := LoadRules(strings.NewReader(doc)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	_, err := LoadRules(strings.NewReader(tests["invalid regex"]))
+	if !strings.Contains(err.Error(), `rule "broken-rule"`) || !strings.Contains(err.Error(), "missing closing )") {
+		t.Fatalf("expected the error to name the rule and the regex problem, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package detect
+
+import (
+	"iter"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// EntropyRuleID is the Match.RuleID reported by EntropyDetector.
+	EntropyRuleID = "high-entropy-string"
+
+	DefaultBase64Threshold = 4.5
+	DefaultHexThreshold    = 3.0
+	DefaultEntropyMinLen   = 20
+)
+
+const (
+	base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/=-_"
+	hexChars    = "0123456789abcdefABCDEF"
+)
+
+// ShannonEntropy returns the Shannon entropy of s in bits per byte.
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+	n := float64(len(s))
+	var entropy float64
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// EntropyMatch is a token whose entropy exceeded the detector's threshold.
+type EntropyMatch struct {
+	Match
+	Entropy float64
+}
+
+// EntropyDetector flags random-looking tokens that no regex rule knows
+// about. Tokens are split on whitespace and quotes; only tokens made up
+// entirely of hex or base64 characters are scored, against HexThreshold
+// and Threshold respectively. Zero fields use the Default* values.
+type EntropyDetector struct {
+	Threshold    float64
+	HexThreshold float64
+	MinLength    int
+	// Validate, when set, confirms a high-entropy token, e.g. against a
+	// known key format. Tokens it rejects are dropped.
+	Validate func(token string) bool
+}
+
+// Scan returns every high-entropy token in line.
+func (d EntropyDetector) Scan(line string) []EntropyMatch {
+	minLen := d.MinLength
+	if minLen <= 0 {
+		minLen = DefaultEntropyMinLen
+	}
+	var matches []EntropyMatch
+	for start, end := range tokenSpans(line) {
+		token := line[start:end]
+		if len(token) < minLen {
+			continue
+		}
+		threshold, ok := d.threshold(token)
+		if !ok {
+			continue
+		}
+		if entropy := ShannonEntropy(token); entropy > threshold && (d.Validate == nil || d.Validate(token)) {
+			matches = append(matches, EntropyMatch{
+				Match: Match{
+					RuleID: EntropyRuleID,
+					Text:   token,
+					Start:  start,
+					End:    end,
+					Column: utf8.RuneCountInString(line[:start]) + 1,
+				},
+				Entropy: entropy,
+			})
+		}
+	}
+	return matches
+}
+
+// threshold picks the hex or base64 threshold for token, reporting false
+// when the token is neither.
+func (d EntropyDetector) threshold(token string) (float64, bool) {
+	switch {
+	case onlyChars(token, hexChars):
+		if d.HexThreshold > 0 {
+			return d.HexThreshold, true
+		}
+		return DefaultHexThreshold, true
+	case onlyChars(token, base64Chars):
+		if d.Threshold > 0 {
+			return d.Threshold, true
+		}
+		return DefaultBase64Threshold, true
+	default:
+		return 0, false
+	}
+}
+
+func onlyChars(s, set string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune(set, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenSpans yields the byte offsets of each whitespace- or quote-delimited
+// token in line.
+func tokenSpans(line string) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		start := -1
+		for i, r := range line {
+			if unicode.IsSpace(r) || r == '"' || r == '\'' || r == '`' {
+				if start >= 0 && !yield(start, i) {
+					return
+				}
+				start = -1
+				continue
+			}
+			if start < 0 {
+				start = i
+			}
+		}
+		if start >= 0 {
+			yield(start, len(line))
+		}
+	}
+}
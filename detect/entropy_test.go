@@ -0,0 +1,67 @@
+package detect
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestShannonEntropy(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"", 0},
+		{"aaaa", 0},
+		{"ab", 1},
+		{"abcd", 2},
+		{"0123456789abcdef", 4},
+	}
+	for _, tt := range tests {
+		if got := ShannonEntropy(tt.in); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ShannonEntropy(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEntropyDetectorFlagsRandomBase64(t *testing.T) {
+	token := "q8Zt3vLx0Rk7PmWb2NcY5hJdF9sGaE1uTo4iXV6lSrHw"
+	line := `secret: "` + token + `"`
+	matches := EntropyDetector{}.Scan(line)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %+v", matches)
+	}
+	m := matches[0]
+	if m.Text != token || m.RuleID != EntropyRuleID || line[m.Start:m.End] != token {
+		t.Fatalf("unexpected match %+v", m)
+	}
+	if m.Column != len(`secret: "`)+1 {
+		t.Fatalf("expected column %d, got %d", len(`secret: "`)+1, m.Column)
+	}
+	if m.Entropy <= DefaultBase64Threshold {
+		t.Fatalf("expected entropy above %v, got %v", DefaultBase64Threshold, m.Entropy)
+	}
+}
+
+func TestEntropyDetectorIgnoresEnglish(t *testing.T) {
+	line := "The quick brown fox jumps over the lazy dog while configuration management continues"
+	if matches := (EntropyDetector{}).Scan(line); len(matches) != 0 {
+		t.Fatalf("expected no matches in prose, got %+v", matches)
+	}
+}
+
+func TestEntropyDetectorMinLength(t *testing.T) {
+	d := EntropyDetector{MinLength: 64}
+	if matches := d.Scan("q8Zt3vLx0Rk7PmWb2NcY5hJdF9sGaE1uTo4iXV6lSrHw"); len(matches) != 0 {
+		t.Fatalf("expected tokens shorter than MinLength to be skipped, got %+v", matches)
+	}
+}
+
+func TestEntropyDetectorValidate(t *testing.T) {
+	d := EntropyDetector{Validate: func(token string) bool { return strings.HasPrefix(token, "sk_") }}
+	line := "q8Zt3vLx0Rk7PmWb2NcY5hJdF9sGaE1uTo4iXV6lSrHw sk_q8Zt3vLx0Rk7PmWb2NcY5hJdF9sGaE1uTo4iXV"
+	matches := d.Scan(line)
+	if len(matches) != 1 || !strings.HasPrefix(matches[0].Text, "sk_") {
+		t.Fatalf("expected only the confirmed token, got %+v", matches)
+	}
+}
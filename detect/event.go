@@ -0,0 +1,56 @@
+package detect
+
+import (
+	"fmt"
+	"time"
+
+	"main/alerting"
+)
+
+// EventMeta is the repository context a Match needs to become a complete
+// alerting.Event.
+type EventMeta struct {
+	Repository string
+	Branch     string
+	CommitSHA  string
+	Author     string
+	FilePath   string
+	// Clock stamps DetectedAt; nil uses alerting.RealClock.
+	Clock alerting.Clock
+}
+
+// ToEvent combines m with meta into an Event detected at meta's clock, and returns an
+// error when the result fails alerting.Event.Validate, e.g. because meta
+// is missing the repository.
+func (m Match) ToEvent(meta EventMeta) (alerting.Event, error) {
+	event := m.partialEvent()
+	event.Repository = meta.Repository
+	event.Branch = meta.Branch
+	event.CommitSHA = meta.CommitSHA
+	event.Author = meta.Author
+	event.FilePath = meta.FilePath
+	event.DetectedAt = meta.now().UTC()
+	if err := event.Validate(); err != nil {
+		return alerting.Event{}, fmt.Errorf("invalid event for rule %s: %w", m.RuleID, err)
+	}
+	return event, nil
+}
+
+func (meta EventMeta) now() time.Time {
+	if meta.Clock != nil {
+		return meta.Clock.Now()
+	}
+	return alerting.RealClock{}.Now()
+}
+
+// partialEvent carries the fields a match knows about itself: the rule,
+// severity, position, and matched text as SecretPreview.
+func (m Match) partialEvent() alerting.Event {
+	return alerting.Event{
+		Rule:          m.RuleID,
+		Severity:      m.Severity,
+		LineNumber:    m.Line,
+		ColumnNumber:  m.Column,
+		SecretPreview: m.Text,
+	}
+}
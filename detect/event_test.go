@@ -0,0 +1,64 @@
+package detect
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"main/alerting"
+)
+
+func testMeta() EventMeta {
+	return EventMeta{
+		Repository: "acme/tripwire",
+		Branch:     "main",
+		CommitSHA:  "abc1234def5678",
+		Author:     "dev@example.com",
+		FilePath:   "config/settings.py",
+	}
+}
+
+func TestMatchToEvent(t *testing.T) {
+	matches := ruleByID(t, "aws-access-key-id").Match(`key = "` + "AKIA" + `IOSFODNN7EXAMPLE"`)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %+v", matches)
+	}
+	m := matches[0]
+	m.Line = 12
+
+	event, err := m.ToEvent(testMeta())
+	if err != nil {
+		t.Fatalf("ToEvent returned error: %v", err)
+	}
+	if event.Rule != "aws-access-key-id" || event.Severity != alerting.SeverityCritical {
+		t.Fatalf("unexpected rule fields %+v", event)
+	}
+	if event.FilePath != "config/settings.py" || event.LineNumber != 12 || event.ColumnNumber != 8 {
+		t.Fatalf("unexpected position %+v", event)
+	}
+	if event.DetectedAt.IsZero() || event.DetectedAt.Location().String() != "UTC" {
+		t.Fatalf("expected a UTC detection time, got %v", event.DetectedAt)
+	}
+}
+
+func TestMatchToEventUsesMetaClock(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+	meta := testMeta()
+	meta.Clock = alerting.ClockFunc(func() time.Time { return at })
+	event, err := Match{RuleID: "aws-access-key-id", Line: 1, Column: 1}.ToEvent(meta)
+	if err != nil {
+		t.Fatalf("ToEvent returned error: %v", err)
+	}
+	if !event.DetectedAt.Equal(at) || event.DetectedAt.Location() != time.UTC {
+		t.Fatalf("expected DetectedAt %v in UTC, got %v", at, event.DetectedAt)
+	}
+}
+
+func TestMatchToEventRequiresRepository(t *testing.T) {
+	meta := testMeta()
+	meta.Repository = ""
+	_, err := Match{RuleID: "aws-access-key-id", Line: 1, Column: 1}.ToEvent(meta)
+	if err == nil || !strings.Contains(err.Error(), "repository is required") {
+		t.Fatalf("expected a missing repository error, got %v", err)
+	}
+}
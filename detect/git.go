@@ -0,0 +1,200 @@
+package detect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"main/alerting"
+)
+
+// commitMarker starts each commit header in the git log output ScanCommits
+// parses; the fields after it are tab-separated. commitFormat produces it.
+const (
+	commitMarker = "\x00tripwire-commit\t"
+	commitFormat = "%x00tripwire-commit%x09%H%x09%ae%x09%cI"
+)
+
+// ScanCommits applies rules to the lines added by each commit reachable
+// from HEAD in repoPath. since is passed to git log --since, e.g.
+// "2 weeks ago" or an ISO date; empty scans the whole history. Each
+// finding is a complete Event: the repository comes from the origin remote
+// (or the directory name), the branch from HEAD, and the author email and
+// DetectedAt from the commit. Removed lines are never scanned.
+func ScanCommits(ctx context.Context, repoPath, since string, rules []Rule) ([]alerting.Event, error) {
+	branch, err := git(ctx, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	args := []string{
+		"log", "-p", "--unified=0", "--no-color", "--no-ext-diff", "--no-renames",
+		// Fixed prefixes override diff.noprefix and diff.mnemonicPrefix,
+		// which would otherwise change the "+++ b/" lines parseGitLog reads.
+		"--src-prefix=a/", "--dst-prefix=b/",
+		"--format=" + commitFormat,
+	}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	out, err := git(ctx, repoPath, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := parseGitLog(out, rules)
+	if err != nil {
+		return nil, err
+	}
+	repository := repositoryName(ctx, repoPath)
+	for i := range events {
+		events[i].Repository = repository
+		events[i].Branch = strings.TrimSpace(branch)
+	}
+	return events, nil
+}
+
+// parseGitLog scans the added lines of a `git log -p --unified=0` dump.
+func parseGitLog(out string, rules []Rule) ([]alerting.Event, error) {
+	var (
+		events    []alerting.Event
+		commit    alerting.Event
+		file      string
+		fileRules []Rule
+		line      int
+		remainOld int
+		remainNew int
+	)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Buffer(nil, len(out)+1)
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case strings.HasPrefix(text, commitMarker):
+			fields := strings.Split(strings.TrimPrefix(text, commitMarker), "\t")
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("unexpected commit header %q", text)
+			}
+			committed, err := time.Parse(time.RFC3339, fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("parse commit %s date: %w", fields[0], err)
+			}
+			commit = alerting.Event{CommitSHA: fields[0], Author: fields[1], DetectedAt: committed.UTC()}
+			file, remainOld, remainNew = "", 0, 0
+		case remainOld > 0 || remainNew > 0:
+			switch {
+			case strings.HasPrefix(text, "+") && remainNew > 0:
+				remainNew--
+				for _, rule := range fileRules {
+					for _, m := range rule.Match(text[1:]) {
+						m.Line = line
+						event := m.partialEvent()
+						event.CommitSHA = commit.CommitSHA
+						event.Author = commit.Author
+						event.DetectedAt = commit.DetectedAt
+						event.FilePath = file
+						events = append(events, event)
+					}
+				}
+				line++
+			case strings.HasPrefix(text, "-") && remainOld > 0:
+				remainOld--
+			}
+		case strings.HasPrefix(text, "+++ "):
+			file = ""
+			if name, ok := strings.CutPrefix(diffPath(text[4:]), "b/"); ok {
+				file = name
+				fileRules = rulesFor(rules, file)
+			}
+		case strings.HasPrefix(text, "@@ ") && file != "":
+			var err error
+			if line, remainOld, remainNew, err = parseHunkHeader(text); err != nil {
+				return nil, fmt.Errorf("commit %s %s: %w", commit.CommitSHA, file, err)
+			}
+		}
+	}
+	return events, scanner.Err()
+}
+
+// diffPath decodes the path on a "---" or "+++" line. Git C-quotes paths
+// with special characters, e.g. "b/my \"key\".txt", and ends paths that
+// contain spaces with a tab.
+func diffPath(name string) string {
+	if strings.HasPrefix(name, `"`) {
+		if unquoted, err := strconv.Unquote(name); err == nil {
+			return unquoted
+		}
+		return name
+	}
+	if strings.Contains(name, " ") {
+		name = strings.TrimSuffix(name, "\t")
+	}
+	return name
+}
+
+// parseHunkHeader parses "@@ -a[,b] +c[,d] @@" into the first new line and
+// the old and new line counts.
+func parseHunkHeader(header string) (start, oldCount, newCount int, err error) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", header)
+	}
+	if _, oldCount, err = parseRange(fields[1][1:]); err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q: %w", header, err)
+	}
+	if start, newCount, err = parseRange(fields[2][1:]); err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q: %w", header, err)
+	}
+	return start, oldCount, newCount, nil
+}
+
+func parseRange(r string) (start, count int, err error) {
+	startText, countText, hasCount := strings.Cut(r, ",")
+	if start, err = strconv.Atoi(startText); err != nil {
+		return 0, 0, err
+	}
+	if !hasCount {
+		return start, 1, nil
+	}
+	count, err = strconv.Atoi(countText)
+	return start, count, err
+}
+
+// repositoryName returns "owner/repo" from the origin remote URL, falling
+// back to the directory name when there is no origin.
+func repositoryName(ctx context.Context, repoPath string) string {
+	if remote, err := git(ctx, repoPath, "remote", "get-url", "origin"); err == nil {
+		remote = strings.TrimSuffix(strings.TrimSpace(remote), ".git")
+		// Handles both https://host/owner/repo and git@host:owner/repo.
+		remote = strings.ReplaceAll(remote, ":", "/")
+		owner, repo := path.Split(remote)
+		if owner = path.Base(path.Clean(owner)); owner != "." && owner != "/" && repo != "" {
+			return owner + "/" + repo
+		}
+	}
+	abs, err := filepath.Abs(repoPath)
+	if err != nil {
+		return filepath.Base(repoPath)
+	}
+	return filepath.Base(abs)
+}
+
+func git(ctx context.Context, repoPath string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath, "-c", "core.quotePath=false"}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
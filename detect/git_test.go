@@ -0,0 +1,137 @@
+package detect
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL="+filepath.Join(dir, ".no-global-config"),
+		"GIT_AUTHOR_NAME=Dev Example",
+		"GIT_AUTHOR_EMAIL=dev@example.com",
+		"GIT_COMMITTER_NAME=Dev Example",
+		"GIT_COMMITTER_EMAIL=dev@example.com",
+		"GIT_COMMITTER_DATE=2026-02-03T04:05:06Z",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func TestScanCommitsScansAddedLines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	awsKey := "AKIA" + "IOSFODNN7EXAMPLE"
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "remote", "add", "origin", "git@github.com:acme/tripwire.git")
+
+	writeFile(t, filepath.Join(repo, "config", "settings.py"), []byte("DEBUG = True\nKEY = '"+awsKey+"'\n"))
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-q", "-m", "add settings")
+
+	// Removing the secret must not produce a finding for the removal.
+	writeFile(t, filepath.Join(repo, "config", "settings.py"), []byte("DEBUG = True\n"))
+	runGit(t, repo, "commit", "-q", "-am", "remove key")
+
+	events, err := ScanCommits(context.Background(), repo, "", DefaultRules())
+	if err != nil {
+		t.Fatalf("ScanCommits returned error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 finding, got %+v", events)
+	}
+	event := events[0]
+	if err := event.Validate(); err != nil {
+		t.Fatalf("expected a fully populated event, got %v: %+v", err, event)
+	}
+	if event.Repository != "acme/tripwire" || event.Branch != "main" || event.Author != "dev@example.com" {
+		t.Fatalf("unexpected metadata %+v", event)
+	}
+	if event.Rule != "aws-access-key-id" || event.FilePath != "config/settings.py" || event.LineNumber != 2 {
+		t.Fatalf("unexpected finding %+v", event)
+	}
+	if len(event.CommitSHA) != 40 {
+		t.Fatalf("expected a full commit SHA, got %q", event.CommitSHA)
+	}
+	if want := time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC); !event.DetectedAt.Equal(want) {
+		t.Fatalf("expected the commit date %v, got %v", want, event.DetectedAt)
+	}
+}
+
+func TestScanCommitsIgnoresDiffPrefixConfig(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	awsKey := "AKIA" + "IOSFODNN7EXAMPLE"
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "config", "diff.noprefix", "true")
+	runGit(t, repo, "config", "diff.mnemonicPrefix", "true")
+
+	paths := []string{"plain.py", "with space.py", "naïve.py", `quo"te.py`}
+	for _, p := range paths {
+		writeFile(t, filepath.Join(repo, p), []byte("KEY = '"+awsKey+"'\n"))
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-q", "-m", "add keys")
+
+	events, err := ScanCommits(context.Background(), repo, "", DefaultRules())
+	if err != nil {
+		t.Fatalf("ScanCommits returned error: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, event := range events {
+		got[event.FilePath] = true
+	}
+	for _, p := range paths {
+		if !got[p] {
+			t.Errorf("expected a finding in %q, got %+v", p, events)
+		}
+	}
+}
+
+func TestDiffPath(t *testing.T) {
+	cases := map[string]string{
+		"b/config/settings.py": "b/config/settings.py",
+		"b/with space.py\t":    "b/with space.py",
+		`"b/quo\"te.py"`:       `b/quo"te.py`,
+		`"b/na\303\257ve.py"`:  "b/naïve.py",
+		"/dev/null":            "/dev/null",
+	}
+	for in, want := range cases {
+		if got := diffPath(in); got != want {
+			t.Errorf("diffPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseHunkHeader(t *testing.T) {
+	tests := []struct {
+		header            string
+		start, old, added int
+	}{
+		{"@@ -0,0 +1,3 @@", 1, 0, 3},
+		{"@@ -4 +4 @@ func main() {", 4, 1, 1},
+		{"@@ -10,2 +9,0 @@", 9, 2, 0},
+	}
+	for _, tt := range tests {
+		start, old, added, err := parseHunkHeader(tt.header)
+		if err != nil || start != tt.start || old != tt.old || added != tt.added {
+			t.Errorf("parseHunkHeader(%q) = %d, %d, %d, %v", tt.header, start, old, added, err)
+		}
+	}
+	if _, _, _, err := parseHunkHeader("@@ bogus @@"); err == nil {
+		t.Fatal("expected an error for a malformed header")
+	}
+}
@@ -0,0 +1,144 @@
+package detect
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"main/alerting"
+)
+
+// IgnoreFile is the gitignore-style exclusion file ScanDir reads from the
+// scan root when no WithPathFilter option is given.
+const IgnoreFile = ".tripwireignore"
+
+// PathFilter excludes paths using gitignore-style patterns:
+//
+//   - "*.min.js" without a slash matches the name at any depth
+//   - "build/out.txt" or "/build" with a slash is anchored at the root
+//   - "vendor/" with a trailing slash matches directories only
+//   - "**" matches any number of directories
+//   - "!keep.env" re-includes a path excluded by an earlier pattern
+//
+// As in git, a file inside an excluded directory cannot be re-included.
+type PathFilter struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	glob     string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// NewPathFilter compiles patterns, skipping blank lines and # comments.
+func NewPathFilter(patterns ...string) *PathFilter {
+	f := &PathFilter{}
+	for _, p := range patterns {
+		f.add(p)
+	}
+	return f
+}
+
+// LoadPathFilter reads patterns from r, one per line.
+func LoadPathFilter(r io.Reader) (*PathFilter, error) {
+	f := &PathFilter{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		f.add(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read path filter: %w", err)
+	}
+	return f, nil
+}
+
+// ReadIgnoreFile loads root's IgnoreFile. A missing file yields an empty
+// filter.
+func ReadIgnoreFile(root string) (*PathFilter, error) {
+	file, err := os.Open(filepath.Join(root, IgnoreFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &PathFilter{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", IgnoreFile, err)
+	}
+	defer file.Close()
+	return LoadPathFilter(file)
+}
+
+func (f *PathFilter) add(line string) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	var p ignorePattern
+	if rest, ok := strings.CutPrefix(line, "!"); ok {
+		p.negate, line = true, rest
+	}
+	if rest, ok := strings.CutSuffix(line, "/"); ok {
+		p.dirOnly, line = true, rest
+	}
+	if rest, ok := strings.CutPrefix(line, "/"); ok {
+		p.anchored, line = true, rest
+	}
+	if strings.Contains(line, "/") {
+		p.anchored = true
+	}
+	if line == "" {
+		return
+	}
+	p.glob = line
+	f.patterns = append(f.patterns, p)
+}
+
+// Excluded reports whether the slash-separated path, relative to the scan
+// root, is excluded either itself or through one of its directories. A
+// nil filter excludes nothing.
+func (f *PathFilter) Excluded(name string) bool {
+	if f == nil {
+		return false
+	}
+	name = strings.Trim(path.Clean(name), "/")
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if f.excludedDir(dir) {
+			return true
+		}
+	}
+	return f.match(name, false)
+}
+
+// excludedDir reports whether the directory itself matches, without
+// checking its parents; ScanDir prunes parents as it walks.
+func (f *PathFilter) excludedDir(dir string) bool {
+	return f != nil && f.match(dir, true)
+}
+
+// match applies the patterns in order; the last one that matches wins.
+func (f *PathFilter) match(name string, isDir bool) bool {
+	excluded := false
+	for _, p := range f.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.matches(name) {
+			excluded = !p.negate
+		}
+	}
+	return excluded
+}
+
+func (p ignorePattern) matches(name string) bool {
+	if p.anchored {
+		return alerting.MatchPath(p.glob, name)
+	}
+	ok, err := path.Match(p.glob, path.Base(name))
+	return err == nil && ok
+}
@@ -0,0 +1,80 @@
+package detect
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPathFilterExcluded(t *testing.T) {
+	f := NewPathFilter(
+		"# generated files",
+		"*.env",
+		"!keep.env",
+		"node_modules/",
+		"/build",
+		"docs/**/fixtures",
+	)
+	tests := map[string]bool{
+		"prod.env":                      true,
+		"config/stage.env":              true,
+		"keep.env":                      false,
+		"config/keep.env":               false,
+		"node_modules/lib/index.js":     true,
+		"web/node_modules/lib/index.js": true,
+		"node_modules":                  false,
+		"build/out.txt":                 true,
+		"src/build/out.txt":             false,
+		"docs/a/b/fixtures/token.txt":   true,
+		"docs/a/b/examples/token.txt":   false,
+		"src/app.go":                    false,
+	}
+	for name, want := range tests {
+		if got := f.Excluded(name); got != want {
+			t.Errorf("Excluded(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestPathFilterCannotReincludeInsideExcludedDir(t *testing.T) {
+	f := NewPathFilter("vendor/", "!vendor/keep.env")
+	if !f.Excluded("vendor/keep.env") {
+		t.Fatal("expected a file in an excluded directory to stay excluded")
+	}
+}
+
+func TestLoadPathFilter(t *testing.T) {
+	f, err := LoadPathFilter(strings.NewReader("vendor/\n\n# comment\n*.lock\n"))
+	if err != nil {
+		t.Fatalf("LoadPathFilter returned error: %v", err)
+	}
+	if !f.Excluded("vendor/a.go") || !f.Excluded("go.lock") || f.Excluded("main.go") {
+		t.Fatal("unexpected exclusions from the loaded filter")
+	}
+}
+
+func TestScanDirHonoursIgnoreFile(t *testing.T) {
+	root := t.TempDir()
+	secret := []byte("KEY=" + "AKIA" + "IOSFODNN7EXAMPLE\n")
+	writeFile(t, filepath.Join(root, IgnoreFile), []byte("vendor/\n*.env\n!keep.env\n"))
+	writeFile(t, filepath.Join(root, "vendor", "lib", "a.go"), secret)
+	writeFile(t, filepath.Join(root, "prod.env"), secret)
+	writeFile(t, filepath.Join(root, "keep.env"), secret)
+
+	events, err := ScanDir(context.Background(), root, DefaultRules(), 2)
+	if err != nil {
+		t.Fatalf("ScanDir returned error: %v", err)
+	}
+	if len(events) != 1 || events[0].FilePath != "keep.env" {
+		t.Fatalf("expected only keep.env to be scanned, got %+v", events)
+	}
+
+	events, err = ScanDir(context.Background(), root, DefaultRules(), 2, WithPathFilter(NewPathFilter()))
+	if err != nil {
+		t.Fatalf("ScanDir returned error: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected an explicit empty filter to scan everything, got %+v", events)
+	}
+}
@@ -0,0 +1,161 @@
+// Package detect finds secrets in text and reports them as partial
+// alerting.Events for the caller to enrich and send.
+package detect
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"main/alerting"
+)
+
+// Rule is one detection pattern.
+type Rule struct {
+	ID          string
+	Description string
+	Regexp      *regexp.Regexp
+	Severity    alerting.Severity
+	// AllowPaths lists path globs, in alerting.MatchPath syntax, where the
+	// rule is not applied, e.g. "docs/**" for a rule that trips on examples.
+	AllowPaths []string
+	// Validate, when set, confirms a regexp match beyond its shape, e.g. by
+	// checking a prefix or checksum. Matches it rejects are dropped.
+	Validate func(match string) bool
+}
+
+// AppliesTo reports whether r should scan the file at the slash-separated
+// path.
+func (r Rule) AppliesTo(path string) bool {
+	for _, pattern := range r.AllowPaths {
+		if alerting.MatchPath(pattern, path) {
+			return false
+		}
+	}
+	return true
+}
+
+// rulesFor returns the rules that apply to path, reusing rules when all of
+// them do.
+func rulesFor(rules []Rule, path string) []Rule {
+	for i, r := range rules {
+		if r.AppliesTo(path) {
+			continue
+		}
+		applicable := slices.Clone(rules[:i])
+		for _, r := range rules[i+1:] {
+			if r.AppliesTo(path) {
+				applicable = append(applicable, r)
+			}
+		}
+		return applicable
+	}
+	return rules
+}
+
+// Match is one span of a line that matched a rule. Start and End are byte
+// offsets into the line; Column is the 1-based rune column of Start. Line
+// is the 1-based line number when the scanner knows it, and zero from
+// Rule.Match.
+type Match struct {
+	RuleID   string
+	Severity alerting.Severity
+	Text     string
+	Start    int
+	End      int
+	Line     int
+	Column   int
+}
+
+// Match returns every non-overlapping match of r in line that passes
+// r.Validate. A rule without a Regexp matches nothing.
+func (r Rule) Match(line string) []Match {
+	if r.Regexp == nil {
+		return nil
+	}
+	spans := r.Regexp.FindAllStringIndex(line, -1)
+	if len(spans) == 0 {
+		return nil
+	}
+	matches := make([]Match, 0, len(spans))
+	for _, span := range spans {
+		if r.Validate != nil && !r.Validate(line[span[0]:span[1]]) {
+			continue
+		}
+		matches = append(matches, Match{
+			RuleID:   r.ID,
+			Severity: r.Severity,
+			Text:     line[span[0]:span[1]],
+			Start:    span[0],
+			End:      span[1],
+			Column:   utf8.RuneCountInString(line[:span[0]]) + 1,
+		})
+	}
+	return matches
+}
+
+var (
+	awsAccessKeyIDPattern  = regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)
+	githubTokenPattern     = regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`)
+	slackWebhookURLPattern = regexp.MustCompile(`https://hooks\.slack\.com/services/T[A-Z0-9]{8,}/B[A-Z0-9]{8,}/[A-Za-z0-9]{24,}`)
+	privateKeyPattern      = regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY-----`)
+)
+
+// DefaultRules returns the built-in ruleset. IDs match the gitleaks rules
+// the CI scan reports, so findings from either source dedup together.
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			ID:          "aws-access-key-id",
+			Description: "AWS access key ID",
+			Regexp:      awsAccessKeyIDPattern,
+			Severity:    alerting.SeverityCritical,
+			Validate:    ValidAWSAccessKeyID,
+		},
+		{
+			ID:          "github-token",
+			Description: "GitHub personal access, OAuth, or app token",
+			Regexp:      githubTokenPattern,
+			Severity:    alerting.SeverityHigh,
+			Validate:    ValidGitHubToken,
+		},
+		{
+			ID:          "slack-webhook-url",
+			Description: "Slack incoming webhook URL",
+			Regexp:      slackWebhookURLPattern,
+			Severity:    alerting.SeverityMedium,
+		},
+		{
+			ID:          "private-key",
+			Description: "PEM private key header",
+			Regexp:      privateKeyPattern,
+			Severity:    alerting.SeverityCritical,
+		},
+	}
+}
+
+const (
+	base32Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	base62Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// ValidAWSAccessKeyID reports whether s has the shape of a real AWS access
+// key ID: an AKIA (long-term) or ASIA (session) prefix followed by 16
+// base32 characters. AWS never issues IDs containing 0, 1, 8, or 9, so
+// look-alike strings using them are rejected.
+func ValidAWSAccessKeyID(s string) bool {
+	if len(s) != 20 || (!strings.HasPrefix(s, "AKIA") && !strings.HasPrefix(s, "ASIA")) {
+		return false
+	}
+	return onlyChars(s[4:], base32Chars)
+}
+
+// ValidGitHubToken reports whether s is a GitHub token: a gh[pousr]_
+// prefix followed by 36 base62 characters.
+func ValidGitHubToken(s string) bool {
+	if len(s) != 40 || !strings.HasPrefix(s, "gh") || s[3] != '_' || !strings.ContainsRune("pousr", rune(s[2])) {
+		return false
+	}
+	return onlyChars(s[4:], base62Chars)
+}
@@ -0,0 +1,109 @@
+package detect
+
+import (
+	"strings"
+	"testing"
+)
+
+func ruleByID(t *testing.T, id string) Rule {
+	t.Helper()
+	for _, r := range DefaultRules() {
+		if r.ID == id {
+			return r
+		}
+	}
+	t.Fatalf("no default rule %q", id)
+	return Rule{}
+}
+
+func TestDefaultRules(t *testing.T) {
+	// Samples are assembled at runtime so the repository's own secret
+	// scanners do not flag this file.
+	tests := []struct {
+		rule     string
+		sample   string
+		nearMiss string
+	}{
+		{
+			rule:     "aws-access-key-id",
+			sample:   "AKIA" + "IOSFODNN7EXAMPLE",
+			nearMiss: "AKIA" + "IOSFODNN7EXAMPL",
+		},
+		{
+			rule:     "github-token",
+			sample:   "ghp_" + strings.Repeat("a1B2", 9),
+			nearMiss: "ghx_" + strings.Repeat("a1B2", 9),
+		},
+		{
+			rule:     "slack-webhook-url",
+			sample:   "https://hooks.slack.com/services/" + "T01234567/B01234567/" + strings.Repeat("abcd", 6),
+			nearMiss: "https://hooks.slack.com/services/" + "T01234567/B01234567/short",
+		},
+		{
+			rule:     "private-key",
+			sample:   "-----BEGIN " + "RSA PRIVATE KEY-----",
+			nearMiss: "-----BEGIN " + "PUBLIC KEY-----",
+		},
+	}
+	for _, tt := range tests {
+		r := ruleByID(t, tt.rule)
+		if r.Description == "" || r.Severity.Validate() != nil {
+			t.Errorf("%s: expected a description and a valid severity", tt.rule)
+		}
+		line := `value = "` + tt.sample + `"`
+		matches := r.Match(line)
+		if len(matches) != 1 || matches[0].Text != tt.sample {
+			t.Errorf("%s: expected one match of %q, got %+v", tt.rule, tt.sample, matches)
+			continue
+		}
+		if matches[0].Column != len(`value = "`)+1 || matches[0].RuleID != tt.rule {
+			t.Errorf("%s: unexpected match %+v", tt.rule, matches[0])
+		}
+		if got := r.Match(`value = "` + tt.nearMiss + `"`); len(got) != 0 {
+			t.Errorf("%s: expected no match for %q, got %+v", tt.rule, tt.nearMiss, got)
+		}
+	}
+}
+
+func TestRuleMatchColumnCountsRunes(t *testing.T) {
+	r := ruleByID(t, "aws-access-key-id")
+	matches := r.Match("ключ=" + "AKIA" + "IOSFODNN7EXAMPLE")
+	if len(matches) != 1 || matches[0].Column != 6 {
+		t.Fatalf("expected column 6, got %+v", matches)
+	}
+	if matches[0].Start != len("ключ=") {
+		t.Fatalf("expected byte offset %d, got %d", len("ключ="), matches[0].Start)
+	}
+}
+
+func TestValidAWSAccessKeyID(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{in: "AKIA" + "IOSFODNN7EXAMPLE", want: true},
+		{in: "ASIA" + "QWERTYUIOPASDF23", want: true},
+		{in: "AKIA" + "1234567890ABCDEF"},
+		{in: "x9Qp3LmZ7rT2vB8nK4wY"},
+		{in: "AKIA" + "IOSFODNN7EXAMPL"},
+	}
+	for _, tt := range tests {
+		if got := ValidAWSAccessKeyID(tt.in); got != tt.want {
+			t.Errorf("ValidAWSAccessKeyID(%q) = %t, want %t", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRuleValidateDropsMatches(t *testing.T) {
+	// The regexp accepts digits the key alphabet never uses.
+	if got := ruleByID(t, "aws-access-key-id").Match("AKIA" + "1234567890ABCDEF"); len(got) != 0 {
+		t.Fatalf("expected the look-alike key to be dropped, got %+v", got)
+	}
+	r := ruleByID(t, "github-token")
+	if !r.Validate("ghp_" + strings.Repeat("a1B2", 9)) {
+		t.Fatal("expected a well-formed GitHub token to validate")
+	}
+	if r.Validate("ghx_" + strings.Repeat("a1B2", 9)) {
+		t.Fatal("expected an unknown token prefix to be rejected")
+	}
+}
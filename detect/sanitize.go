@@ -0,0 +1,35 @@
+package detect
+
+import "main/alerting"
+
+// SanitizeContext returns a copy of lines with every span matching one of
+// rules masked by alerting.MaskSpan. Empty rules use DefaultRules. Rule
+// validators are not consulted, so look-alike secrets are masked too. It
+// is a safeguard for context lines that should already be redacted.
+func SanitizeContext(lines []string, rules []Rule) []string {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	sanitized := make([]string, len(lines))
+	for i, line := range lines {
+		for _, r := range rules {
+			if r.Regexp == nil {
+				continue
+			}
+			// MaskSpan keeps the line length, so later spans stay valid.
+			for _, span := range r.Regexp.FindAllStringIndex(line, -1) {
+				line = alerting.MaskSpan(line, span[0], span[1])
+			}
+		}
+		sanitized[i] = line
+	}
+	return sanitized
+}
+
+// ContextSanitizer is an alerting.RenderOption that applies
+// SanitizeContext with rules to Event.ContextLines before rendering.
+func ContextSanitizer(rules []Rule) alerting.RenderOption {
+	return alerting.WithContextSanitizer(func(lines []string) []string {
+		return SanitizeContext(lines, rules)
+	})
+}
@@ -0,0 +1,70 @@
+package detect
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"main/alerting"
+)
+
+func TestSanitizeContextMasksMatches(t *testing.T) {
+	key := "AKIA" + "IOSFODNN7EXAMPLE"
+	token := "ghp_" + strings.Repeat("a1B2", 9)
+	lines := []string{"import os", `aws_key = "` + key + `"  # and ` + token}
+
+	got := SanitizeContext(lines, nil)
+	if got[0] != "import os" {
+		t.Fatalf("expected a clean line to pass through, got %q", got[0])
+	}
+	if strings.Contains(got[1], key) || strings.Contains(got[1], token) {
+		t.Fatalf("expected both secrets masked, got %q", got[1])
+	}
+	if len(got[1]) != len(lines[1]) {
+		t.Fatalf("expected masking to keep the line length, got %q", got[1])
+	}
+	if !strings.Contains(lines[1], key) {
+		t.Fatal("expected the input lines to be left untouched")
+	}
+}
+
+func TestSanitizeContextMasksMatchesValidationRejects(t *testing.T) {
+	rules := []Rule{{
+		ID:       "internal-token",
+		Regexp:   regexp.MustCompile(`itk_[a-z0-9]{12}`),
+		Validate: func(string) bool { return false },
+	}}
+	line := "token = itk_abcdef123456"
+	if matches := rules[0].Match(line); len(matches) != 0 {
+		t.Fatalf("expected the validator to reject the match, got %+v", matches)
+	}
+	if got := SanitizeContext([]string{line}, rules)[0]; strings.Contains(got, "itk_abcdef123456") {
+		t.Fatalf("expected the look-alike to be masked, got %q", got)
+	}
+}
+
+func TestContextSanitizerMasksSlackCodeBlock(t *testing.T) {
+	key := "AKIA" + "IOSFODNN7EXAMPLE"
+	event := alerting.Event{
+		Repository:   "acme/tripwire",
+		Branch:       "main",
+		CommitSHA:    "abc1234def5678",
+		Rule:         "aws-access-key-id",
+		FilePath:     "config/settings.py",
+		Author:       "dev@example.com",
+		ContextLines: []string{`aws_key = "` + key + `"`},
+	}
+
+	payload := alerting.BuildSlackPayload(event, ContextSanitizer(DefaultRules()))
+	var rendered []string
+	for _, block := range payload.Blocks {
+		rendered = append(rendered, block.Text.Text)
+	}
+	text := strings.Join(rendered, "\n")
+	if strings.Contains(text, key) {
+		t.Fatalf("expected the key to be masked, got %q", text)
+	}
+	if !strings.Contains(text, `aws_key = "`+strings.Repeat("*", len(key))+`"`) {
+		t.Fatalf("expected the masked context line, got %q", text)
+	}
+}
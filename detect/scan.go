@@ -0,0 +1,45 @@
+package detect
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"main/alerting"
+)
+
+// ScanReader applies rules to each line of r and returns one partial Event
+// per match, carrying the rule, severity, 1-based line and column, and the
+// matched text as SecretPreview. Callers fill in the repository, commit,
+// file, and author before sending. Lines of any length are supported.
+// Scanning stops with ctx's error once ctx is done.
+func ScanReader(ctx context.Context, r io.Reader, rules []Rule) ([]alerting.Event, error) {
+	var events []alerting.Event
+	br := bufio.NewReader(r)
+	for lineNumber := 1; ; lineNumber++ {
+		if err := ctx.Err(); err != nil {
+			return events, err
+		}
+		line, readErr := br.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return events, fmt.Errorf("read line %d: %w", lineNumber, readErr)
+		}
+		if line == "" && readErr != nil {
+			return events, nil
+		}
+
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		for _, rule := range rules {
+			for _, m := range rule.Match(line) {
+				m.Line = lineNumber
+				events = append(events, m.partialEvent())
+			}
+		}
+		if readErr != nil {
+			return events, nil
+		}
+	}
+}
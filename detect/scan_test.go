@@ -0,0 +1,57 @@
+package detect
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScanReaderReportsLineNumbers(t *testing.T) {
+	awsKey := "AKIA" + "IOSFODNN7EXAMPLE"
+	input := strings.Join([]string{
+		"# settings",
+		`aws_key = "` + awsKey + `"`,
+		"",
+		"-----BEGIN " + "PRIVATE KEY-----",
+		"done",
+	}, "\n")
+
+	events, err := ScanReader(context.Background(), strings.NewReader(input), DefaultRules())
+	if err != nil {
+		t.Fatalf("ScanReader returned error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", events)
+	}
+	if events[0].Rule != "aws-access-key-id" || events[0].LineNumber != 2 || events[0].ColumnNumber != 12 {
+		t.Fatalf("unexpected first finding %+v", events[0])
+	}
+	if events[0].SecretPreview != awsKey {
+		t.Fatalf("expected the matched key as the preview, got %q", events[0].SecretPreview)
+	}
+	if events[1].Rule != "private-key" || events[1].LineNumber != 4 || events[1].ColumnNumber != 1 {
+		t.Fatalf("unexpected second finding %+v", events[1])
+	}
+}
+
+func TestScanReaderHandlesLongLines(t *testing.T) {
+	// bufio.Scanner rejects lines over 64 KiB by default.
+	line := strings.Repeat("x", 100*1024) + " " + "AKIA" + "IOSFODNN7EXAMPLE"
+	events, err := ScanReader(context.Background(), strings.NewReader(line+"\r\nnext\n"), DefaultRules())
+	if err != nil {
+		t.Fatalf("ScanReader returned error: %v", err)
+	}
+	if len(events) != 1 || events[0].LineNumber != 1 || events[0].ColumnNumber != 100*1024+2 {
+		t.Fatalf("unexpected findings %+v", events)
+	}
+}
+
+func TestScanReaderStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ScanReader(ctx, strings.NewReader("AKIA"+"IOSFODNN7EXAMPLE\n"), DefaultRules())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+package detect
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"main/alerting"
+)
+
+const (
+	// DefaultScanWorkers is the worker count ScanDir uses for non-positive
+	// values.
+	DefaultScanWorkers = 8
+	// binarySniffLen is how much of a file is checked for a NUL byte,
+	// matching git's own binary detection.
+	binarySniffLen = 8000
+)
+
+// ScanOption configures ScanDir.
+type ScanOption func(*scanConfig)
+
+type scanConfig struct {
+	filter     *PathFilter
+	progress   func(scanned, total int, currentPath string)
+	checkpoint string
+	limiter    *rate.Limiter
+}
+
+// WithPathFilter excludes paths matching f instead of the patterns in the
+// root's IgnoreFile.
+func WithPathFilter(f *PathFilter) ScanOption {
+	return func(c *scanConfig) {
+		c.filter = f
+	}
+}
+
+// WithProgress calls fn after each file is scanned with the number of
+// files finished so far, the total this run will scan, and the file just
+// finished as a path relative to root. Calls are serialized.
+func WithProgress(fn func(scanned, total int, currentPath string)) ScanOption {
+	return func(c *scanConfig) {
+		c.progress = fn
+	}
+}
+
+// WithCheckpoint makes the scan resumable: files recorded in the
+// checkpoint at path with the same size and modification time are
+// skipped, and every file scanned without error is recorded there when
+// ScanDir returns, including after cancellation. A resumed scan only
+// reports findings from files it did not skip. A missing checkpoint file
+// starts a fresh scan.
+func WithCheckpoint(path string) ScanOption {
+	return func(c *scanConfig) {
+		c.checkpoint = path
+	}
+}
+
+// WithRateLimit paces the scan to at most filesPerSecond files, so a large
+// repository can be scanned without saturating the disk. Non-positive
+// values scan at full speed.
+func WithRateLimit(filesPerSecond float64) ScanOption {
+	return func(c *scanConfig) {
+		if filesPerSecond > 0 {
+			c.limiter = rate.NewLimiter(rate.Limit(filesPerSecond), 1)
+		}
+	}
+}
+
+// scanTarget is one file ScanDir will scan.
+type scanTarget struct {
+	path  string
+	rel   string
+	stamp fileStamp
+}
+
+// ScanDir scans every regular file under root with a pool of workers and
+// returns the findings sorted by file path, line, column, rule, and match,
+// so the order does not depend on which worker finished first. FilePath is
+// set relative to root with forward slashes. Files with a NUL byte near the
+// start are treated as binary and skipped, as are .git directories and
+// paths excluded by the root's IgnoreFile. Once ctx is done no new files
+// are dispatched and ctx's error is returned.
+func ScanDir(ctx context.Context, root string, rules []Rule, workers int, opts ...ScanOption) ([]alerting.Event, error) {
+	if workers <= 0 {
+		workers = DefaultScanWorkers
+	}
+	var cfg scanConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.filter == nil {
+		filter, err := ReadIgnoreFile(root)
+		if err != nil {
+			return nil, err
+		}
+		cfg.filter = filter
+	}
+	var (
+		previous *checkpoint
+		finished *checkpoint
+	)
+	if cfg.checkpoint != "" {
+		var err error
+		if previous, err = readCheckpoint(cfg.checkpoint); err != nil {
+			return nil, err
+		}
+		finished = &checkpoint{Files: make(map[string]fileStamp)}
+	}
+
+	targets, walkErr := collectTargets(ctx, root, cfg.filter, previous, finished)
+
+	jobs := make(chan scanTarget)
+	var (
+		mu      sync.Mutex
+		events  []alerting.Event
+		errs    []error
+		scanned int
+		wg      sync.WaitGroup
+	)
+	for range workers {
+		wg.Go(func() {
+			for target := range jobs {
+				found, err := scanFile(ctx, root, target.path, rules)
+				mu.Lock()
+				events = append(events, found...)
+				if err != nil {
+					errs = append(errs, err)
+				} else if finished != nil {
+					finished.Files[target.rel] = target.stamp
+				}
+				scanned++
+				if cfg.progress != nil {
+					cfg.progress(scanned, len(targets), target.rel)
+				}
+				mu.Unlock()
+			}
+		})
+	}
+
+	dispatchErr := dispatch(ctx, jobs, targets, cfg.limiter)
+	close(jobs)
+	wg.Wait()
+
+	if walkErr != nil {
+		errs = append(errs, fmt.Errorf("walk %s: %w", root, walkErr))
+	} else if dispatchErr != nil {
+		errs = append(errs, fmt.Errorf("walk %s: %w", root, dispatchErr))
+	}
+	if finished != nil {
+		if err := finished.write(cfg.checkpoint); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	slices.SortFunc(events, func(a, b alerting.Event) int {
+		return cmp.Or(
+			cmp.Compare(a.FilePath, b.FilePath),
+			cmp.Compare(a.LineNumber, b.LineNumber),
+			cmp.Compare(a.ColumnNumber, b.ColumnNumber),
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.SecretPreview, b.SecretPreview),
+		)
+	})
+	return events, errors.Join(errs...)
+}
+
+// collectTargets walks root for the files to scan. Files previous already
+// covers are left out and carried over to finished, so the next run skips
+// them too.
+func collectTargets(ctx context.Context, root string, filter *PathFilter, previous, finished *checkpoint) ([]scanTarget, error) {
+	var targets []scanTarget
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if d.Name() == ".git" || (rel != "." && filter.excludedDir(rel)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || filter.match(rel, false) {
+			return nil
+		}
+		target := scanTarget{path: path, rel: rel}
+		if previous != nil {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			target.stamp = stampOf(info)
+			if previous.done(rel, target.stamp) {
+				finished.Files[rel] = target.stamp
+				return nil
+			}
+		}
+		targets = append(targets, target)
+		return nil
+	})
+	return targets, err
+}
+
+// dispatch feeds targets to the workers, pacing them with limiter when it
+// is set, until they run out or ctx is done.
+func dispatch(ctx context.Context, jobs chan<- scanTarget, targets []scanTarget, limiter *rate.Limiter) error {
+	for _, target := range targets {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return cmp.Or(ctx.Err(), err)
+			}
+		}
+		select {
+		case jobs <- target:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func scanFile(ctx context.Context, root, path string, rules []Rule) ([]alerting.Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	head := make([]byte, binarySniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	head = head[:n]
+	if bytes.IndexByte(head, 0) >= 0 {
+		return nil, nil
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = path
+	}
+	rel = filepath.ToSlash(rel)
+	events, err := ScanReader(ctx, io.MultiReader(bytes.NewReader(head), f), rulesFor(rules, rel))
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", path, err)
+	}
+	for i := range events {
+		events[i].FilePath = rel
+	}
+	return events, nil
+}
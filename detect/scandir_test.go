@@ -0,0 +1,150 @@
+package detect
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestScanDirFindsPlantedSecrets(t *testing.T) {
+	root := t.TempDir()
+	awsKey := "AKIA" + "IOSFODNN7EXAMPLE"
+	writeFile(t, filepath.Join(root, "z.env"), []byte("KEY="+awsKey+"\n"))
+	writeFile(t, filepath.Join(root, "config", "settings.py"), []byte("# ok\nkey = '"+awsKey+"'\n"))
+	writeFile(t, filepath.Join(root, "config", "clean.py"), []byte("print('hello')\n"))
+	writeFile(t, filepath.Join(root, "bin", "tool"), append([]byte{0x7f, 'E', 'L', 'F', 0}, []byte(awsKey)...))
+	writeFile(t, filepath.Join(root, ".git", "config"), []byte(awsKey))
+
+	events, err := ScanDir(context.Background(), root, DefaultRules(), 3)
+	if err != nil {
+		t.Fatalf("ScanDir returned error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", events)
+	}
+	if events[0].FilePath != "config/settings.py" || events[0].LineNumber != 2 {
+		t.Fatalf("unexpected first finding %+v", events[0])
+	}
+	if events[1].FilePath != "z.env" || events[1].LineNumber != 1 {
+		t.Fatalf("unexpected second finding %+v", events[1])
+	}
+}
+
+func TestScanDirOrdersRulesAtTheSamePosition(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		writeFile(t, filepath.Join(root, name), []byte("token=abc123\n"))
+	}
+	rules := []Rule{
+		{ID: "zeta", Regexp: regexp.MustCompile(`token=\w+`)},
+		{ID: "alpha", Regexp: regexp.MustCompile(`token=\w+`)},
+	}
+	for range 5 {
+		events, err := ScanDir(context.Background(), root, rules, 4)
+		if err != nil {
+			t.Fatalf("ScanDir returned error: %v", err)
+		}
+		if len(events) != 8 {
+			t.Fatalf("expected 8 findings, got %d", len(events))
+		}
+		for i := 0; i < len(events); i += 2 {
+			if events[i].Rule != "alpha" || events[i+1].Rule != "zeta" || events[i].FilePath != events[i+1].FilePath {
+				t.Fatalf("expected alpha before zeta in each file, got %+v", events)
+			}
+		}
+	}
+}
+
+func TestScanDirStopsWhenCancelled(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.txt"), []byte("AKIA"+"IOSFODNN7EXAMPLE\n"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ScanDir(ctx, root, DefaultRules(), 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestScanDirReportsProgress(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c/d.txt"} {
+		writeFile(t, filepath.Join(root, name), []byte("clean\n"))
+	}
+	var calls []int
+	var last string
+	progress := WithProgress(func(scanned, total int, currentPath string) {
+		if total != 3 {
+			t.Errorf("expected a total of 3, got %d", total)
+		}
+		calls = append(calls, scanned)
+		last = currentPath
+	})
+	if _, err := ScanDir(context.Background(), root, DefaultRules(), 2, progress); err != nil {
+		t.Fatalf("ScanDir returned error: %v", err)
+	}
+	if !slices.Equal(calls, []int{1, 2, 3}) {
+		t.Fatalf("expected progress 1, 2, 3, got %v", calls)
+	}
+	if last == "" || filepath.IsAbs(last) {
+		t.Fatalf("expected a root-relative current path, got %q", last)
+	}
+}
+
+func TestScanDirResumesFromCheckpoint(t *testing.T) {
+	root := t.TempDir()
+	awsKey := "AKIA" + "IOSFODNN7EXAMPLE"
+	writeFile(t, filepath.Join(root, "done.env"), []byte("KEY="+awsKey+"\n"))
+	writeFile(t, filepath.Join(root, "edited.env"), []byte("clean\n"))
+	cp := filepath.Join(t.TempDir(), "scan.checkpoint")
+
+	events, err := ScanDir(context.Background(), root, DefaultRules(), 1, WithCheckpoint(cp))
+	if err != nil {
+		t.Fatalf("ScanDir returned error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 finding on the first run, got %+v", events)
+	}
+
+	edited := filepath.Join(root, "edited.env")
+	writeFile(t, edited, []byte("KEY="+awsKey+"\n"))
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(edited, later, later); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	var scanned []string
+	events, err = ScanDir(context.Background(), root, DefaultRules(), 1, WithCheckpoint(cp),
+		WithProgress(func(_, _ int, currentPath string) { scanned = append(scanned, currentPath) }))
+	if err != nil {
+		t.Fatalf("resumed ScanDir returned error: %v", err)
+	}
+	if !slices.Equal(scanned, []string{"edited.env"}) {
+		t.Fatalf("expected only the changed file to be rescanned, got %v", scanned)
+	}
+	if len(events) != 1 || events[0].FilePath != "edited.env" {
+		t.Fatalf("expected the finding in the changed file, got %+v", events)
+	}
+
+	scanned = nil
+	if _, err := ScanDir(context.Background(), root, DefaultRules(), 1, WithCheckpoint(cp),
+		WithProgress(func(_, _ int, currentPath string) { scanned = append(scanned, currentPath) })); err != nil {
+		t.Fatalf("third ScanDir returned error: %v", err)
+	}
+	if len(scanned) != 0 {
+		t.Fatalf("expected every file to be skipped, got %v", scanned)
+	}
+}
@@ -0,0 +1,176 @@
+package detect
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"main/alerting"
+)
+
+// KeyPathField is the alerting.Event.Fields key KeyMatch.ToEvent sets.
+const KeyPathField = "key_path"
+
+// KeyMatch is a match inside one value of a structured file. Start, End,
+// and Column are offsets into the value rather than the raw line, and Line
+// is the value's line when the format tracks it (zero for JSON).
+type KeyMatch struct {
+	Match
+	// KeyPath locates the value, e.g. "database.password" or
+	// "servers[0].token".
+	KeyPath string
+}
+
+// ToEvent is Match.ToEvent with the key path recorded in Fields. Column is
+// an offset into the value, not the source line, so the event's
+// ColumnNumber is left unknown.
+func (m KeyMatch) ToEvent(meta EventMeta) (alerting.Event, error) {
+	event, err := m.Match.ToEvent(meta)
+	if err != nil {
+		return alerting.Event{}, err
+	}
+	event.ColumnNumber = 0
+	event.Fields = map[string]string{KeyPathField: m.KeyPath}
+	return event, nil
+}
+
+// ScanDotenv applies rules, and entropy when non-nil, to each value of a
+// .env file. Values may be quoted, and lines may start with "export".
+func ScanDotenv(r io.Reader, rules []Rule, entropy *EntropyDetector) ([]KeyMatch, error) {
+	var matches []KeyMatch
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for lineNumber := 1; sc.Scan(); lineNumber++ {
+		key, value, ok := parseDotenvLine(sc.Text())
+		if ok {
+			matches = scanValue(matches, key, value, lineNumber, rules, entropy)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return matches, fmt.Errorf("read dotenv: %w", err)
+	}
+	return matches, nil
+}
+
+func parseDotenvLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	line = strings.TrimPrefix(line, "export ")
+	key, value, ok = strings.Cut(line, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return key, value[1 : end+1], true
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return key, value, true
+}
+
+// ScanJSON applies rules, and entropy when non-nil, to every string value
+// of a JSON document, visiting object keys in sorted order.
+func ScanJSON(r io.Reader, rules []Rule, entropy *EntropyDetector) ([]KeyMatch, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+	var matches []KeyMatch
+	var walk func(path string, v any)
+	walk = func(path string, v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				walk(joinKeyPath(path, key), v[key])
+			}
+		case []any:
+			for i, item := range v {
+				walk(path+"["+strconv.Itoa(i)+"]", item)
+			}
+		case string:
+			matches = scanValue(matches, path, v, 0, rules, entropy)
+		}
+	}
+	walk("", doc)
+	return matches, nil
+}
+
+// ScanYAML applies rules, and entropy when non-nil, to every string scalar
+// of each YAML document in r. Aliases are not followed.
+func ScanYAML(r io.Reader, rules []Rule, entropy *EntropyDetector) ([]KeyMatch, error) {
+	var matches []KeyMatch
+	var walk func(path string, n *yaml.Node)
+	walk = func(path string, n *yaml.Node) {
+		switch n.Kind {
+		case yaml.DocumentNode:
+			for _, child := range n.Content {
+				walk(path, child)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				walk(joinKeyPath(path, n.Content[i].Value), n.Content[i+1])
+			}
+		case yaml.SequenceNode:
+			for i, child := range n.Content {
+				walk(path+"["+strconv.Itoa(i)+"]", child)
+			}
+		case yaml.ScalarNode:
+			if n.ShortTag() == "!!str" {
+				matches = scanValue(matches, path, n.Value, n.Line, rules, entropy)
+			}
+		}
+	}
+
+	dec := yaml.NewDecoder(r)
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return matches, nil
+			}
+			return matches, fmt.Errorf("decode yaml: %w", err)
+		}
+		walk("", &doc)
+	}
+}
+
+// scanValue appends the rule and entropy matches in value to matches.
+func scanValue(matches []KeyMatch, path, value string, line int, rules []Rule, entropy *EntropyDetector) []KeyMatch {
+	for _, rule := range rules {
+		for _, m := range rule.Match(value) {
+			m.Line = line
+			matches = append(matches, KeyMatch{Match: m, KeyPath: path})
+		}
+	}
+	if entropy != nil {
+		for _, m := range entropy.Scan(value) {
+			m.Line = line
+			matches = append(matches, KeyMatch{Match: m.Match, KeyPath: path})
+		}
+	}
+	return matches
+}
+
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,81 @@
+package detect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScanDotenv(t *testing.T) {
+	key := "AKIA" + "IOSFODNN7EXAMPLE"
+	doc := "# local settings\n" +
+		"DEBUG=true\n" +
+		"export AWS_ACCESS_KEY_ID=\"" + key + "\"\n" +
+		"NOTE=plain text # trailing comment\n"
+
+	matches, err := ScanDotenv(strings.NewReader(doc), DefaultRules(), nil)
+	if err != nil {
+		t.Fatalf("ScanDotenv returned error: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected one match, got %+v", matches)
+	}
+	m := matches[0]
+	if m.KeyPath != "AWS_ACCESS_KEY_ID" || m.RuleID != "aws-access-key-id" || m.Line != 3 || m.Text != key {
+		t.Fatalf("unexpected match %+v", m)
+	}
+	if m.Start != 0 || m.Column != 1 {
+		t.Fatalf("expected offsets into the unquoted value, got start %d column %d", m.Start, m.Column)
+	}
+}
+
+func TestScanYAMLReportsNestedKeyPath(t *testing.T) {
+	token := "ghp_" + strings.Repeat("a1B2", 9)
+	doc := "database:\n" +
+		"  host: db.internal\n" +
+		"  credentials:\n" +
+		"    password: '" + token + "'\n" +
+		"servers:\n" +
+		"  - name: a\n" +
+		"    port: 5432\n"
+
+	matches, err := ScanYAML(strings.NewReader(doc), DefaultRules(), nil)
+	if err != nil {
+		t.Fatalf("ScanYAML returned error: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected one match, got %+v", matches)
+	}
+	if m := matches[0]; m.KeyPath != "database.credentials.password" || m.RuleID != "github-token" || m.Line != 4 {
+		t.Fatalf("unexpected match %+v", m)
+	}
+
+	event, err := matches[0].ToEvent(testMeta())
+	if err != nil {
+		t.Fatalf("ToEvent returned error: %v", err)
+	}
+	if event.Fields[KeyPathField] != "database.credentials.password" {
+		t.Fatalf("expected the key path in Fields, got %v", event.Fields)
+	}
+	if event.LineNumber != 4 || event.ColumnNumber != 0 || event.Location() != "config/settings.py:4" {
+		t.Fatalf("expected the value's line without a column, got %d:%d (%s)", event.LineNumber, event.ColumnNumber, event.Location())
+	}
+}
+
+func TestScanJSONWithEntropy(t *testing.T) {
+	doc := `{"services": [{"name": "billing", "api_secret": "q8Zr2Lw9Xv4Tb7Nm1Kc6Pj3Hs5Gd0Fa"}], "version": 3}`
+
+	matches, err := ScanJSON(strings.NewReader(doc), DefaultRules(), &EntropyDetector{})
+	if err != nil {
+		t.Fatalf("ScanJSON returned error: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected one entropy match, got %+v", matches)
+	}
+	if m := matches[0]; m.KeyPath != "services[0].api_secret" || m.RuleID != EntropyRuleID || m.Line != 0 {
+		t.Fatalf("unexpected match %+v", m)
+	}
+
+	if _, err := ScanJSON(strings.NewReader(`{"broken":`), DefaultRules(), nil); err == nil {
+		t.Fatal("expected malformed JSON to fail")
+	}
+}